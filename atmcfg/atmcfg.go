@@ -116,6 +116,12 @@ func newProcessMap(processes []string) map[string]bool {
 	return processesMap
 }
 
+// reachedGoal reports whether status shows every process reached the goal state of the version of out,
+// the condition to move to the next step of a change made in steps.
+func reachedGoal(out *opsmngr.AutomationConfig, status *opsmngr.AutomationStatus) bool {
+	return status != nil && status.GoalVersion >= out.Version && IsGoalState(status)
+}
+
 func IsGoalState(s *opsmngr.AutomationStatus) bool {
 	for _, p := range s.Processes {
 		if p.LastGoalVersionAchieved != s.GoalVersion {
@@ -32,28 +32,107 @@ const (
 	keyLength                      = 500
 	mongoCR                        = "MONGODB-CR"
 	scramSha256                    = "SCRAM-SHA-256"
+	plain                          = "PLAIN"
+	gssapi                         = "GSSAPI"
+	x509                           = "MONGODB-X509"
 )
 
+// MechanismOpt configures EnableMechanism.
+type MechanismOpt func(*mechanismOptions)
+
+type mechanismOptions struct {
+	ldap       *opsmngr.LDAP
+	kerberos   *opsmngr.Kerberos
+	agent      string
+	user       string
+	password   string
+	groupDN    string
+	keytabPath string
+	pemKeyFile string
+}
+
+// WithLDAP sets the LDAP settings of the deployment, required to enable PLAIN.
+func WithLDAP(ldap *opsmngr.LDAP) MechanismOpt {
+	return func(o *mechanismOptions) {
+		o.ldap = ldap
+	}
+}
+
+// WithKerberos sets the Kerberos settings of the deployment, required to enable GSSAPI.
+func WithKerberos(kerberos *opsmngr.Kerberos) MechanismOpt {
+	return func(o *mechanismOptions) {
+		o.kerberos = kerberos
+	}
+}
+
+// WithLDAPAgent makes the automation agent authenticate as the LDAP user with PLAIN,
+// groupDN is the LDAP group of the agent when using LDAP authorization and can be empty.
+func WithLDAPAgent(user, password, groupDN string) MechanismOpt {
+	return func(o *mechanismOptions) {
+		o.agent = plain
+		o.user = user
+		o.password = password
+		o.groupDN = groupDN
+	}
+}
+
+// WithKerberosAgent makes the automation agent authenticate as the Kerberos principal with GSSAPI,
+// keytabPath is the path of the keytab file of the principal on the agent hosts.
+func WithKerberosAgent(principal, keytabPath string) MechanismOpt {
+	return func(o *mechanismOptions) {
+		o.agent = gssapi
+		o.user = principal
+		o.keytabPath = keytabPath
+	}
+}
+
+// WithX509Agent makes the automation agent authenticate with MONGODB-X509,
+// subject is the subject of the agent certificate found at pemKeyFile on the agent hosts.
+func WithX509Agent(subject, pemKeyFile string) MechanismOpt {
+	return func(o *mechanismOptions) {
+		o.agent = x509
+		o.user = subject
+		o.pemKeyFile = pemKeyFile
+	}
+}
+
 // EnableMechanism allows you to enable a given set of authentication mechanisms to an opsmngr.AutomationConfig.
-// This method currently only supports MONGODB-CR, and SCRAM-SHA-256.
-func EnableMechanism(out *opsmngr.AutomationConfig, m []string) error {
+// This method supports MONGODB-CR, SCRAM-SHA-256, PLAIN (LDAP), GSSAPI (Kerberos) and MONGODB-X509.
+//
+// PLAIN requires WithLDAP, GSSAPI requires WithKerberos and MONGODB-X509 requires the agent TLS settings,
+// see SetAgentTLS. The automation agent uses SCRAM credentials it generates unless one of WithLDAPAgent,
+// WithKerberosAgent or WithX509Agent sets its identity, which is required when m has no SCRAM mechanism.
+func EnableMechanism(out *opsmngr.AutomationConfig, m []string, opts ...MechanismOpt) error {
+	o := &mechanismOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if err := o.validate(out, m); err != nil {
+		return err
+	}
+
 	out.Auth.Disabled = false
 	for _, v := range m {
-		if v != mongoCR && v != scramSha256 {
-			return fmt.Errorf("unsupported mechanism %s", v)
-		}
-		if v == scramSha256 && out.Auth.AutoAuthMechanism == "" {
+		if v == scramSha256 && out.Auth.AutoAuthMechanism == "" && o.agent == "" {
 			out.Auth.AutoAuthMechanism = v
 		}
 		if !stringInSlice(out.Auth.DeploymentAuthMechanisms, v) {
 			out.Auth.DeploymentAuthMechanisms = append(out.Auth.DeploymentAuthMechanisms, v)
 		}
-		if !stringInSlice(out.Auth.AutoAuthMechanisms, v) {
+		if (v == mongoCR || v == scramSha256) && !stringInSlice(out.Auth.AutoAuthMechanisms, v) {
 			out.Auth.AutoAuthMechanisms = append(out.Auth.AutoAuthMechanisms, v)
 		}
 	}
+	if o.ldap != nil {
+		out.LDAP = o.ldap
+	}
+	if o.kerberos != nil {
+		out.Kerberos = o.kerberos
+	}
 
-	if out.Auth.AutoUser == "" && out.Auth.AutoPwd == "" {
+	if o.agent != "" {
+		o.setAgent(out)
+	} else if out.Auth.AutoUser == "" && out.Auth.AutoPwd == "" {
 		if err := setAutoUser(out); err != nil {
 			return err
 		}
@@ -75,6 +154,81 @@ func EnableMechanism(out *opsmngr.AutomationConfig, m []string) error {
 	return nil
 }
 
+func (o *mechanismOptions) validate(out *opsmngr.AutomationConfig, m []string) error {
+	scram := false
+	for _, v := range m {
+		switch v {
+		case mongoCR, scramSha256:
+			scram = true
+		case plain:
+			if o.ldap == nil && out.LDAP == nil {
+				return fmt.Errorf("%s requires the LDAP settings", plain)
+			}
+		case gssapi:
+			if o.kerberos == nil && out.Kerberos == nil {
+				return fmt.Errorf("%s requires the Kerberos settings", gssapi)
+			}
+		case x509:
+			if out.TLS == nil || out.TLS.CAFilePath == "" {
+				return fmt.Errorf("%s requires the agent TLS settings", x509)
+			}
+		default:
+			return fmt.Errorf("unsupported mechanism %s", v)
+		}
+	}
+	if o.ldap != nil && o.ldap.Servers == "" {
+		return errors.New("LDAP servers must be set")
+	}
+	if o.kerberos != nil && o.kerberos.ServiceName == "" {
+		return errors.New("kerberos service name must be set")
+	}
+
+	switch o.agent {
+	case "":
+		// the agent keeps its current identity if the deployment still supports it
+		current := out.Auth.AutoAuthMechanism
+		enabled := !out.Auth.Disabled && stringInSlice(out.Auth.DeploymentAuthMechanisms, current)
+		if !scram && !enabled && !stringInSlice(m, current) {
+			return fmt.Errorf("the automation agent identity must be set to enable %v", m)
+		}
+		return nil
+	case plain:
+		if o.password == "" {
+			return errors.New("LDAP agent password must be set")
+		}
+	case gssapi:
+		if o.keytabPath == "" {
+			return errors.New("kerberos agent keytab path must be set")
+		}
+	case x509:
+		if o.pemKeyFile == "" {
+			return errors.New("x.509 agent PEM key file must be set")
+		}
+	}
+	if o.user == "" {
+		return fmt.Errorf("%s agent user must be set", o.agent)
+	}
+	if !stringInSlice(m, o.agent) {
+		return fmt.Errorf("the automation agent can't use %s, it's not in %v", o.agent, m)
+	}
+	return nil
+}
+
+// setAgent sets the identity of the automation agent, replacing any previous one.
+// The PEM key file of the agent is only set for x.509, the agent still needs it for other mechanisms
+// when processes require client certificates.
+func (o *mechanismOptions) setAgent(out *opsmngr.AutomationConfig) {
+	out.Auth.AutoAuthMechanism = o.agent
+	out.Auth.AutoAuthMechanisms = []string{o.agent}
+	out.Auth.AutoUser = o.user
+	out.Auth.AutoPwd = o.password
+	out.Auth.AutoLdapGroupDN = o.groupDN
+	out.Auth.AutoKerberosKeytabPath = o.keytabPath
+	if o.agent == x509 && out.TLS != nil {
+		out.TLS.AutoPEMKeyFilePath = o.pemKeyFile
+	}
+}
+
 func setAutoUser(out *opsmngr.AutomationConfig) error {
 	var err error
 	out.Auth.AutoUser = automationAgentName
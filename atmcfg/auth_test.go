@@ -83,6 +83,138 @@ func TestEnableMechanism(t *testing.T) {
 	})
 }
 
+func TestEnableMechanism_LDAP(t *testing.T) {
+	ldap := &opsmngr.LDAP{
+		Servers:           "ldap.example.com:636",
+		BindMethod:        "simple",
+		BindQueryUser:     "cn=admin,dc=example,dc=com",
+		BindQueryPassword: "password",
+		TransportSecurity: "tls",
+	}
+	t.Run("without LDAP settings", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := EnableMechanism(config, []string{plain}, WithLDAPAgent("agent", "password", "")); err == nil {
+			t.Fatal("EnableMechanism() expected an error but got none")
+		}
+	})
+	t.Run("without agent identity", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := EnableMechanism(config, []string{plain}, WithLDAP(ldap)); err == nil {
+			t.Fatal("EnableMechanism() expected an error but got none")
+		}
+	})
+	t.Run("with SCRAM agent", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := EnableMechanism(config, []string{scramSha256, plain}, WithLDAP(ldap)); err != nil {
+			t.Fatalf("EnableMechanism() unexpected error: %v", err)
+		}
+		if config.Auth.AutoAuthMechanism == plain || config.Auth.AutoPwd == "" {
+			t.Errorf("expected a SCRAM agent, got %s", config.Auth.AutoAuthMechanism)
+		}
+		if config.LDAP != ldap {
+			t.Error("config.LDAP not set")
+		}
+	})
+	t.Run("with LDAP agent", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		err := EnableMechanism(config, []string{plain}, WithLDAP(ldap), WithLDAPAgent("agent", "password", "cn=agents,dc=example,dc=com"))
+		if err != nil {
+			t.Fatalf("EnableMechanism() unexpected error: %v", err)
+		}
+		a := config.Auth
+		if a.AutoAuthMechanism != plain || len(a.AutoAuthMechanisms) != 1 || a.AutoAuthMechanisms[0] != plain {
+			t.Errorf("expected the agent to use %s, got %s %v", plain, a.AutoAuthMechanism, a.AutoAuthMechanisms)
+		}
+		if a.AutoUser != "agent" || a.AutoPwd != "password" || a.AutoLdapGroupDN != "cn=agents,dc=example,dc=com" {
+			t.Errorf("unexpected agent identity %s %s %s", a.AutoUser, a.AutoPwd, a.AutoLdapGroupDN)
+		}
+		if a.DeploymentAuthMechanisms[0] != plain {
+			t.Errorf("DeploymentAuthMechanisms not set")
+		}
+	})
+	t.Run("agent mechanism not enabled", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := EnableMechanism(config, []string{scramSha256}, WithLDAPAgent("agent", "password", "")); err == nil {
+			t.Fatal("EnableMechanism() expected an error but got none")
+		}
+	})
+}
+
+func TestEnableMechanism_GSSAPI(t *testing.T) {
+	kerberos := &opsmngr.Kerberos{ServiceName: "mongodb"}
+	t.Run("without keytab", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := EnableMechanism(config, []string{gssapi}, WithKerberos(kerberos), WithKerberosAgent("agent@EXAMPLE.COM", "")); err == nil {
+			t.Fatal("EnableMechanism() expected an error but got none")
+		}
+	})
+	t.Run("without service name", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		err := EnableMechanism(config, []string{gssapi}, WithKerberos(&opsmngr.Kerberos{}), WithKerberosAgent("agent@EXAMPLE.COM", "/etc/agent.keytab"))
+		if err == nil {
+			t.Fatal("EnableMechanism() expected an error but got none")
+		}
+	})
+	t.Run("with Kerberos agent", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		err := EnableMechanism(config, []string{gssapi}, WithKerberos(kerberos), WithKerberosAgent("agent@EXAMPLE.COM", "/etc/agent.keytab"))
+		if err != nil {
+			t.Fatalf("EnableMechanism() unexpected error: %v", err)
+		}
+		a := config.Auth
+		if a.AutoAuthMechanism != gssapi || a.AutoUser != "agent@EXAMPLE.COM" || a.AutoKerberosKeytabPath != "/etc/agent.keytab" || a.AutoPwd != "" {
+			t.Errorf("unexpected agent identity %s %s %s", a.AutoAuthMechanism, a.AutoUser, a.AutoKerberosKeytabPath)
+		}
+		if config.Kerberos.ServiceName != "mongodb" {
+			t.Error("config.Kerberos not set")
+		}
+	})
+}
+
+func TestEnableMechanism_X509(t *testing.T) {
+	const subject = "CN=mms-automation,OU=agents,O=MongoDB"
+	t.Run("without agent TLS", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := EnableMechanism(config, []string{x509}, WithX509Agent(subject, "/etc/agent.pem")); err == nil {
+			t.Fatal("EnableMechanism() expected an error but got none")
+		}
+	})
+	t.Run("with x.509 agent", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := SetAgentTLS(config, "/etc/ca.pem", "REQUIRE"); err != nil {
+			t.Fatalf("SetAgentTLS() unexpected error: %v", err)
+		}
+		if err := EnableMechanism(config, []string{x509}, WithX509Agent(subject, "/etc/agent.pem")); err != nil {
+			t.Fatalf("EnableMechanism() unexpected error: %v", err)
+		}
+		if config.Auth.AutoAuthMechanism != x509 || config.Auth.AutoUser != subject {
+			t.Errorf("unexpected agent identity %s %s", config.Auth.AutoAuthMechanism, config.Auth.AutoUser)
+		}
+		if config.TLS.AutoPEMKeyFilePath != "/etc/agent.pem" {
+			t.Errorf("expected the agent certificate to be set, got %s", config.TLS.AutoPEMKeyFilePath)
+		}
+		if err := Validate(config); err != nil {
+			t.Errorf("Validate() returned an error: %v", err)
+		}
+	})
+	t.Run("switching to an LDAP agent keeps the agent certificate", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := SetAgentTLS(config, "/etc/ca.pem", "REQUIRE"); err != nil {
+			t.Fatalf("SetAgentTLS() unexpected error: %v", err)
+		}
+		if err := EnableMechanism(config, []string{x509}, WithX509Agent(subject, "/etc/agent.pem")); err != nil {
+			t.Fatalf("EnableMechanism() unexpected error: %v", err)
+		}
+		ldap := &opsmngr.LDAP{Servers: "ldap.example.com:636"}
+		if err := EnableMechanism(config, []string{x509, plain}, WithLDAP(ldap), WithLDAPAgent("agent", "password", "")); err != nil {
+			t.Fatalf("EnableMechanism() unexpected error: %v", err)
+		}
+		if config.Auth.AutoAuthMechanism != plain || config.TLS.AutoPEMKeyFilePath != "/etc/agent.pem" {
+			t.Errorf("expected an LDAP agent keeping its certificate, got %s %s", config.Auth.AutoAuthMechanism, config.TLS.AutoPEMKeyFilePath)
+		}
+	})
+}
+
 func TestConfigureScramCredentials(t *testing.T) {
 	u := &opsmngr.MongoDBUser{
 		Username: "test",
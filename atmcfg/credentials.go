@@ -0,0 +1,137 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.mongodb.org/ops-manager/search"
+)
+
+// ErrUserNotFound means the user is not one of the users wanted by the config.
+var ErrUserNotFound = errors.New("user not found")
+
+// RotateUserPassword sets new SCRAM credentials for the user, for each of its mechanisms,
+// or both SCRAM-SHA-1 and SCRAM-SHA-256 if it has none. The cleartext initial password and
+// the legacy password hash are cleared so the previous password stops working.
+// Only users in Auth.UsersWanted can be rotated, not the ones being removed with Auth.UsersDeleted.
+func RotateUserPassword(out *opsmngr.AutomationConfig, username, database, password string) error {
+	if password == "" {
+		return errors.New("password must be set")
+	}
+	u, err := wantedUser(out, username, database)
+	if err != nil {
+		return err
+	}
+
+	var mechanisms []string
+	if u.Mechanisms != nil {
+		mechanisms = *u.Mechanisms
+	}
+	sha1Creds, sha256Creds := u.ScramSha1Creds, u.ScramSha256Creds
+	if len(mechanisms) == 0 || stringInSlice(mechanisms, scramSha1) {
+		if sha1Creds, err = newScramSha1Creds(u, password); err != nil {
+			return err
+		}
+	}
+	if len(mechanisms) == 0 || stringInSlice(mechanisms, scramSha256) {
+		if sha256Creds, err = newScramSha256Creds(u, password); err != nil {
+			return err
+		}
+	}
+
+	u.ScramSha1Creds, u.ScramSha256Creds = sha1Creds, sha256Creds
+	u.InitPassword = ""
+	u.Password = ""
+	return nil
+}
+
+// UpgradeUserMechanisms moves the user to SCRAM-SHA-256 only, dropping its SCRAM-SHA-1 credentials.
+// SCRAM-SHA-256 credentials can't be derived from SCRAM-SHA-1 ones, so the password is required,
+// and SCRAM-SHA-256 must be enabled for the deployment.
+func UpgradeUserMechanisms(out *opsmngr.AutomationConfig, username, database, password string) error {
+	if password == "" {
+		return errors.New("password must be set")
+	}
+	if !out.Auth.Disabled && !stringInSlice(out.Auth.DeploymentAuthMechanisms, scramSha256) {
+		return fmt.Errorf("%s must be enabled for the deployment first", scramSha256)
+	}
+	u, err := wantedUser(out, username, database)
+	if err != nil {
+		return err
+	}
+
+	creds, err := newScramSha256Creds(u, password)
+	if err != nil {
+		return err
+	}
+	u.Mechanisms = &[]string{scramSha256}
+	u.ScramSha256Creds = creds
+	u.ScramSha1Creds = nil
+	u.InitPassword = ""
+	u.Password = ""
+	return nil
+}
+
+// RotateAgentPassword rotates the password of the automation agent user and reports whether it's done.
+//
+// The first call sets Auth.NewAutoPwd, the agents then update the user on every process.
+// Following calls make the new password the current one once status shows the agents reached
+// the goal state of a config version with the new password, see the steps in the package documentation.
+func RotateAgentPassword(out *opsmngr.AutomationConfig, status *opsmngr.AutomationStatus) (bool, error) {
+	a := &out.Auth
+	if a.Disabled {
+		return false, errors.New("auth is disabled")
+	}
+	switch a.AutoAuthMechanism {
+	case mongoCR, scramSha1, scramSha256:
+	default:
+		return false, fmt.Errorf("the automation agent doesn't use a password with %s", a.AutoAuthMechanism)
+	}
+	if a.AutoPwd == "" {
+		return false, errors.New("the automation agent has no password to rotate")
+	}
+
+	if a.NewAutoPwd == "" {
+		var err error
+		if a.NewAutoPwd, err = generateRandomASCIIString(keyLength); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	if !reachedGoal(out, status) {
+		return false, nil
+	}
+	a.AutoPwd, a.NewAutoPwd = a.NewAutoPwd, ""
+	return true, nil
+}
+
+// wantedUser returns the user from Auth.UsersWanted, unless it's also in Auth.UsersDeleted.
+func wantedUser(out *opsmngr.AutomationConfig, username, database string) (*opsmngr.MongoDBUser, error) {
+	i, found := search.MongoDBUsers(out.Auth.UsersWanted, func(u *opsmngr.MongoDBUser) bool {
+		return u.Username == username && u.Database == database
+	})
+	if !found {
+		return nil, fmt.Errorf("%w: %s is not managed by the automation", ErrUserNotFound, qualifiedName(username, database))
+	}
+	for _, d := range out.Auth.UsersDeleted {
+		if d.User == username && stringInSlice(d.DBs, database) {
+			return nil, fmt.Errorf("user %s is being deleted", qualifiedName(username, database))
+		}
+	}
+	return out.Auth.UsersWanted[i], nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func TestRotateUserPassword(t *testing.T) {
+	t.Run("rotate", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		u := config.Auth.UsersWanted[0]
+		u.InitPassword = "initial"
+		if err := ConfigureScramCredentials(u, "initial"); err != nil {
+			t.Fatalf("ConfigureScramCredentials() unexpected error: %v", err)
+		}
+		old256 := u.ScramSha256Creds
+		if err := RotateUserPassword(config, u.Username, u.Database, "rotated"); err != nil {
+			t.Fatalf("RotateUserPassword() unexpected error: %v", err)
+		}
+		if u.InitPassword != "" {
+			t.Error("InitPassword not cleared")
+		}
+		if u.ScramSha1Creds == nil || u.ScramSha1Creds.Salt == "" {
+			t.Error("SCRAM-SHA-1 credentials not set")
+		}
+		// the fixture user only has SCRAM-SHA-1
+		if u.ScramSha256Creds != old256 {
+			t.Error("SCRAM-SHA-256 credentials changed")
+		}
+	})
+	t.Run("all mechanisms", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		u := config.Auth.UsersWanted[0]
+		u.Mechanisms = nil
+		if err := RotateUserPassword(config, u.Username, u.Database, "rotated"); err != nil {
+			t.Fatalf("RotateUserPassword() unexpected error: %v", err)
+		}
+		if u.ScramSha1Creds == nil || u.ScramSha256Creds == nil {
+			t.Error("SCRAM credentials not set")
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		if err := RotateUserPassword(config, "other", "test", "rotated"); !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("RotateUserPassword() expected ErrUserNotFound, got %v", err)
+		}
+	})
+	t.Run("being deleted", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		config.Auth.UsersDeleted = []*opsmngr.MongoDBDeletedUser{{User: "test", DBs: []string{"test"}}}
+		if err := RotateUserPassword(config, "test", "test", "rotated"); err == nil {
+			t.Fatal("RotateUserPassword() expected an error but got none")
+		}
+	})
+}
+
+func TestUpgradeUserMechanisms(t *testing.T) {
+	t.Run("upgrade", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		u := config.Auth.UsersWanted[0]
+		if err := ConfigureScramCredentials(u, "password"); err != nil {
+			t.Fatalf("ConfigureScramCredentials() unexpected error: %v", err)
+		}
+		if err := UpgradeUserMechanisms(config, u.Username, u.Database, "password"); err != nil {
+			t.Fatalf("UpgradeUserMechanisms() unexpected error: %v", err)
+		}
+		if len(*u.Mechanisms) != 1 || (*u.Mechanisms)[0] != scramSha256 {
+			t.Errorf("expected only %s, got %v", scramSha256, *u.Mechanisms)
+		}
+		if u.ScramSha1Creds != nil || u.ScramSha256Creds == nil {
+			t.Error("expected only SCRAM-SHA-256 credentials")
+		}
+	})
+	t.Run("mechanism not enabled", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		config.Auth.Disabled = false
+		config.Auth.DeploymentAuthMechanisms = []string{scramSha1}
+		if err := UpgradeUserMechanisms(config, "test", "test", "password"); err == nil {
+			t.Fatal("UpgradeUserMechanisms() expected an error but got none")
+		}
+	})
+}
+
+func TestRotateAgentPassword(t *testing.T) {
+	config := automationConfigWithoutMongoDBUsers()
+	if err := EnableMechanism(config, []string{scramSha256}); err != nil {
+		t.Fatalf("EnableMechanism() unexpected error: %v", err)
+	}
+	config.Version = 2
+	old := config.Auth.AutoPwd
+
+	done, err := RotateAgentPassword(config, statusAt(1, 1))
+	if err != nil || done {
+		t.Fatalf("RotateAgentPassword() = %v, %v", done, err)
+	}
+	newPwd := config.Auth.NewAutoPwd
+	if newPwd == "" || newPwd == old {
+		t.Fatal("NewAutoPwd not set")
+	}
+	if err := Validate(config); err != nil {
+		t.Errorf("Validate() returned an error: %v", err)
+	}
+
+	// the config with the new password is not applied yet
+	config.Version = 3
+	if done, _ := RotateAgentPassword(config, statusAt(2, 2)); done || config.Auth.AutoPwd != old {
+		t.Fatal("RotateAgentPassword() completed before the goal state")
+	}
+	if done, _ := RotateAgentPassword(config, statusAt(3, 3)); !done {
+		t.Fatal("RotateAgentPassword() expected to be done")
+	}
+	if config.Auth.AutoPwd != newPwd || config.Auth.NewAutoPwd != "" {
+		t.Error("new password not made current")
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+// ChangeType is the type of change made to an element of an automation config.
+type ChangeType string
+
+const (
+	ChangeCreate ChangeType = "create"
+	ChangeUpdate ChangeType = "update"
+	ChangeDelete ChangeType = "delete"
+)
+
+// Kinds of elements of an automation config reported by Diff.
+const (
+	KindConfig            = "config"
+	KindAuth              = "auth"
+	KindProcess           = "process"
+	KindReplicaSet        = "replicaSet"
+	KindMember            = "member"
+	KindSharding          = "sharding"
+	KindUser              = "user"
+	KindRole              = "role"
+	KindIndex             = "index"
+	KindMonitoringVersion = "monitoringVersion"
+	KindBackupVersion     = "backupVersion"
+)
+
+const sensitiveValue = "(sensitive value)"
+
+// kindPaths are the JSON paths of the elements of each kind in an automation config.
+var kindPaths = map[string]string{
+	KindConfig:            "",
+	KindAuth:              "auth",
+	KindProcess:           "processes",
+	KindReplicaSet:        "replicaSets",
+	KindMember:            "replicaSets.members",
+	KindSharding:          "sharding",
+	KindUser:              "auth.usersWanted",
+	KindRole:              "roles",
+	KindIndex:             "indexConfigs",
+	KindMonitoringVersion: "monitoringVersions",
+	KindBackupVersion:     "backupVersions",
+}
+
+// FieldChange is a change to a single field, Path is the JSON path of the field within its element.
+type FieldChange struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// Change is a change to an element of an automation config, identified by its Kind and ID
+// (the process name, the replica set _id, the member host, user@db, etc.).
+type Change struct {
+	Type   ChangeType
+	Kind   string
+	ID     string
+	Fields []*FieldChange // Fields changed, only set for updates
+}
+
+// Changes is the list of changes between two automation configs.
+type Changes []*Change
+
+// Diff returns the changes needed to go from current to desired.
+// Elements are matched by their identity rather than their position,
+// so reordering processes, replica sets, members, users, roles or indexes is not a change.
+// The version of the configs is ignored as it's managed by Ops Manager.
+func Diff(current, desired *opsmngr.AutomationConfig) Changes {
+	if current == nil {
+		current = &opsmngr.AutomationConfig{}
+	}
+	if desired == nil {
+		desired = &opsmngr.AutomationConfig{}
+	}
+
+	var changes Changes
+	changes = append(changes, diffElement(KindConfig, "", current, desired,
+		"version", "auth", "processes", "replicaSets", "sharding", "roles", "indexConfigs", "monitoringVersions", "backupVersions")...)
+	changes = append(changes, diffElement(KindAuth, "", current.Auth, desired.Auth, "usersWanted")...)
+	changes = append(changes, diffKeyed(KindUser, current.Auth.UsersWanted, desired.Auth.UsersWanted, userKey)...)
+	changes = append(changes, diffKeyed(KindRole, current.Roles, desired.Roles, roleKey)...)
+	changes = append(changes, diffKeyed(KindProcess, current.Processes, desired.Processes, processKey)...)
+	changes = append(changes, diffReplicaSets(current.ReplicaSets, desired.ReplicaSets)...)
+	changes = append(changes, diffKeyed(KindSharding, current.Sharding, desired.Sharding, shardingKey)...)
+	changes = append(changes, diffKeyed(KindIndex, current.IndexConfigs, desired.IndexConfigs, indexKey)...)
+	changes = append(changes, diffKeyed(KindMonitoringVersion, current.MonitoringVersions, desired.MonitoringVersions, configVersionKey)...)
+	changes = append(changes, diffKeyed(KindBackupVersion, current.BackupVersions, desired.BackupVersions, configVersionKey)...)
+
+	return changes
+}
+
+// IsEmpty reports whether there are no changes.
+func (c Changes) IsEmpty() bool {
+	return len(c) == 0
+}
+
+// Plan renders the changes as a human-readable plan.
+func (c Changes) Plan() string {
+	if c.IsEmpty() {
+		return "No changes. The automation config is up-to-date.\n"
+	}
+
+	var b strings.Builder
+	var created, updated, deleted int
+	for _, change := range c {
+		switch change.Type {
+		case ChangeCreate:
+			created++
+			fmt.Fprintf(&b, "  + %s\n", change.title())
+		case ChangeDelete:
+			deleted++
+			fmt.Fprintf(&b, "  - %s\n", change.title())
+		case ChangeUpdate:
+			updated++
+			fmt.Fprintf(&b, "  ~ %s\n", change.title())
+			for _, f := range change.Fields {
+				path := joinPath(kindPaths[change.Kind], f.Path)
+				fmt.Fprintf(&b, "      ~ %s: %s => %s\n", f.Path, renderValue(path, f.Old), renderValue(path, f.New))
+			}
+		}
+	}
+	fmt.Fprintf(&b, "\nPlan: %d to add, %d to change, %d to remove.\n", created, updated, deleted)
+
+	return b.String()
+}
+
+func (c *Change) title() string {
+	if c.ID == "" {
+		return c.Kind
+	}
+	return fmt.Sprintf("%s %q", c.Kind, c.ID)
+}
+
+// renderValue renders the value of the field at path, the JSON path of the field in the automation config.
+func renderValue(path string, v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+	if opsmngr.IsSensitivePath(path) {
+		return sensitiveValue
+	}
+	b, err := json.Marshal(redactValue(path, v))
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// redactValue replaces the secrets nested in v, so a whole element, like a new ldap section, can be rendered.
+func redactValue(path string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			if opsmngr.IsSensitivePath(joinPath(path, k)) {
+				redacted[k] = sensitiveValue
+				continue
+			}
+			redacted[k] = redactValue(joinPath(path, k), e)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, e := range v {
+			redacted[i] = redactValue(path, e)
+		}
+		return redacted
+	}
+	return v
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func userKey(u *opsmngr.MongoDBUser) string {
+	return qualifiedName(u.Username, u.Database)
+}
+
+func roleKey(r *opsmngr.CustomRole) string {
+	return qualifiedName(r.Role, r.Database)
+}
+
+func processKey(p *opsmngr.Process) string {
+	return p.Name
+}
+
+func shardingKey(s *opsmngr.ShardingConfig) string {
+	return s.Name
+}
+
+func configVersionKey(v *opsmngr.ConfigVersion) string {
+	return v.Hostname
+}
+
+func indexKey(i *opsmngr.IndexConfig) string {
+	keys := make([]string, len(i.Key))
+	for j, k := range i.Key {
+		keys[j] = strings.Join(k, ":")
+	}
+	return fmt.Sprintf("%s/%s.%s/{%s}", i.RSName, i.DBName, i.CollectionName, strings.Join(keys, ","))
+}
+
+func diffReplicaSets(current, desired []*opsmngr.ReplicaSet) Changes {
+	changes := diffKeyed(KindReplicaSet, current, desired, func(rs *opsmngr.ReplicaSet) string {
+		return rs.ID
+	}, "members")
+
+	desiredByID := make(map[string]*opsmngr.ReplicaSet, len(desired))
+	for _, rs := range desired {
+		desiredByID[rs.ID] = rs
+	}
+	for _, rs := range current {
+		d, ok := desiredByID[rs.ID]
+		if !ok {
+			continue
+		}
+		memberChanges := diffKeyed(KindMember, rs.Members, d.Members, func(m opsmngr.Member) string {
+			return m.Host
+		})
+		for _, c := range memberChanges {
+			c.ID = rs.ID + "/" + c.ID
+		}
+		changes = append(changes, memberChanges...)
+	}
+
+	return changes
+}
+
+// diffKeyed compares two lists of elements identified by key, ignoring the excluded JSON fields.
+func diffKeyed[T any](kind string, current, desired []T, key func(T) string, exclude ...string) Changes {
+	desiredByKey := make(map[string]T, len(desired))
+	for _, d := range desired {
+		desiredByKey[key(d)] = d
+	}
+
+	var changes Changes
+	seen := make(map[string]bool, len(current))
+	for _, c := range current {
+		k := key(c)
+		seen[k] = true
+		d, ok := desiredByKey[k]
+		if !ok {
+			changes = append(changes, &Change{Type: ChangeDelete, Kind: kind, ID: k})
+			continue
+		}
+		changes = append(changes, diffElement(kind, k, c, d, exclude...)...)
+	}
+	for _, d := range desired {
+		if k := key(d); !seen[k] {
+			seen[k] = true
+			changes = append(changes, &Change{Type: ChangeCreate, Kind: kind, ID: k})
+		}
+	}
+
+	return changes
+}
+
+// diffElement compares the JSON representation of two elements, ignoring the excluded top level fields.
+func diffElement(kind, id string, current, desired interface{}, exclude ...string) Changes {
+	c, d := toJSONMap(current), toJSONMap(desired)
+	for _, e := range exclude {
+		delete(c, e)
+		delete(d, e)
+	}
+
+	var fields []*FieldChange
+	diffValues("", c, d, &fields)
+	if len(fields) == 0 {
+		return nil
+	}
+	return Changes{{Type: ChangeUpdate, Kind: kind, ID: id, Fields: fields}}
+}
+
+func diffValues(path string, current, desired interface{}, fields *[]*FieldChange) {
+	c, cIsMap := current.(map[string]interface{})
+	d, dIsMap := desired.(map[string]interface{})
+	if !cIsMap || !dIsMap {
+		if !reflect.DeepEqual(current, desired) {
+			*fields = append(*fields, &FieldChange{Path: path, Old: current, New: desired})
+		}
+		return
+	}
+
+	keys := make([]string, 0, len(c)+len(d))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	for k := range d {
+		if _, ok := c[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		diffValues(p, c[k], d[k], fields)
+	}
+}
+
+func toJSONMap(v interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return m
+	}
+	_ = json.Unmarshal(b, &m)
+	return m
+}
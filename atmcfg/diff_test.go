@@ -0,0 +1,192 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("no changes", func(t *testing.T) {
+		current := automationConfigWithOneShardedCluster(clusterName, false)
+		desired := automationConfigWithOneShardedCluster(clusterName, false)
+		desired.Version = current.Version + 1
+		// reordering is not a change
+		desired.Processes[0], desired.Processes[1] = desired.Processes[1], desired.Processes[0]
+
+		if changes := Diff(current, desired); !changes.IsEmpty() {
+			t.Errorf("expected no changes, got\n%s", changes.Plan())
+		}
+	})
+	t.Run("shutdown", func(t *testing.T) {
+		current := automationConfigWithOneReplicaSet(clusterName, false)
+		desired := automationConfigWithOneReplicaSet(clusterName, false)
+		Shutdown(desired, clusterName)
+
+		expected := Changes{
+			{
+				Type: ChangeUpdate,
+				Kind: KindProcess,
+				ID:   clusterName + "_0",
+				Fields: []*FieldChange{
+					{Path: "disabled", Old: false, New: true},
+				},
+			},
+		}
+		if diff := deep.Equal(Diff(current, desired), expected); diff != nil {
+			t.Error(diff)
+		}
+	})
+	t.Run("members", func(t *testing.T) {
+		current := automationConfigWithOneReplicaSet(clusterName, false)
+		desired := automationConfigWithOneReplicaSet(clusterName, false)
+		desired.ReplicaSets[0].Members[0].Priority = 0
+		desired.ReplicaSets[0].Members = append(desired.ReplicaSets[0].Members, opsmngr.Member{Host: clusterName + "_1"})
+
+		expected := Changes{
+			{
+				Type: ChangeUpdate,
+				Kind: KindMember,
+				ID:   clusterName + "/" + clusterName + "_0",
+				Fields: []*FieldChange{
+					{Path: "priority", Old: float64(1), New: float64(0)},
+				},
+			},
+			{Type: ChangeCreate, Kind: KindMember, ID: clusterName + "/" + clusterName + "_1"},
+		}
+		if diff := deep.Equal(Diff(current, desired), expected); diff != nil {
+			t.Error(diff)
+		}
+	})
+	t.Run("users and indexes", func(t *testing.T) {
+		current := automationConfigWithMongoDBUsers()
+		desired := automationConfigWithoutMongoDBUsers()
+		desired.IndexConfigs = automationConfigWithIndexConfig().IndexConfigs
+
+		expected := Changes{
+			{Type: ChangeDelete, Kind: KindUser, ID: "test@test"},
+			{Type: ChangeCreate, Kind: KindIndex, ID: "myReplicaSet/test.test/{test:test}"},
+		}
+		if diff := deep.Equal(Diff(current, desired), expected); diff != nil {
+			t.Error(diff)
+		}
+	})
+	t.Run("remove cluster", func(t *testing.T) {
+		current := automationConfigWithOneShardedCluster(clusterName, false)
+		desired := automationConfigWithOneShardedCluster(clusterName, false)
+		RemoveByClusterName(desired, clusterName)
+
+		changes := Diff(current, desired)
+		if changes.IsEmpty() {
+			t.Fatal("expected changes")
+		}
+		for _, c := range changes {
+			if c.Type != ChangeDelete {
+				t.Errorf("expected only deletions, got %s %s %q", c.Type, c.Kind, c.ID)
+			}
+		}
+	})
+}
+
+func TestChanges_Plan(t *testing.T) {
+	current := automationConfigWithOneReplicaSet(clusterName, false)
+	current.Auth.AutoPwd = "old"
+	desired := automationConfigWithOneReplicaSet(clusterName, false)
+	desired.Auth.AutoPwd = "new"
+	desired.Processes[0].Version = "4.4.0"
+	desired.Processes = append(desired.Processes, &opsmngr.Process{Name: clusterName + "_1"})
+
+	expected := `  ~ auth
+      ~ autoPwd: (sensitive value) => (sensitive value)
+  ~ process "cluster_1_0"
+      ~ version: "4.2.2" => "4.4.0"
+  + process "cluster_1_1"
+
+Plan: 1 to add, 2 to change, 0 to remove.
+`
+	plan := Diff(current, desired).Plan()
+	if plan != expected {
+		t.Errorf("Plan() =\n%s\nexpected\n%s", plan, expected)
+	}
+	if strings.Contains(plan, "new") {
+		t.Error("Plan() must not render sensitive values")
+	}
+
+	if plan := Diff(current, current).Plan(); !strings.HasPrefix(plan, "No changes.") {
+		t.Errorf("Plan() = %s, expected no changes", plan)
+	}
+}
+
+func TestChanges_PlanRedactsSecrets(t *testing.T) {
+	current := automationConfigWithOneReplicaSet(clusterName, false)
+	current.Auth.UsersWanted = []*opsmngr.MongoDBUser{{
+		Username:         "user",
+		Database:         "admin",
+		ScramSha256Creds: &opsmngr.ScramShaCreds{IterationCount: 15000, Salt: "oldSalt", StoredKey: "oldStoredKey", ServerKey: "oldServerKey"},
+	}}
+	current.LDAP = &opsmngr.LDAP{BindQueryPassword: "oldBindQueryPassword"}
+	desired := automationConfigWithOneReplicaSet(clusterName, false)
+	desired.Auth.Key = "newKeyfile"
+	desired.Auth.UsersWanted = []*opsmngr.MongoDBUser{{
+		Username:         "user",
+		Database:         "admin",
+		ScramSha256Creds: &opsmngr.ScramShaCreds{IterationCount: 15000, Salt: "newSalt", StoredKey: "newStoredKey", ServerKey: "newServerKey"},
+	}}
+	desired.LDAP = &opsmngr.LDAP{BindQueryPassword: "newBindQueryPassword"}
+	desired.TLS = &opsmngr.SSL{AutoPEMKeyFilePwd: "newAutoPEMKeyFilePwd"}
+	desired.Prometheus = &opsmngr.Prometheus{TLSPemPassword: "newTLSPemPassword"}
+	desired.Processes[0].Args26.NET.TLS = &opsmngr.TLS{CertificateKeyFilePassword: "newCertificateKeyFilePassword", ClusterPassword: "newClusterPassword"}
+
+	plan := Diff(current, desired).Plan()
+	for _, secret := range []string{
+		"Salt", "StoredKey", "ServerKey", "BindQueryPassword", "Keyfile",
+		"AutoPEMKeyFilePwd", "TLSPemPassword", "CertificateKeyFilePassword", "ClusterPassword",
+	} {
+		if strings.Contains(plan, secret) {
+			t.Errorf("Plan() renders the secret %s:\n%s", secret, plan)
+		}
+	}
+	if !strings.Contains(plan, "scramSha256Creds.salt: (sensitive value) => (sensitive value)") {
+		t.Errorf("Plan() must list the changed secrets, got\n%s", plan)
+	}
+}
+
+func TestRenderValue(t *testing.T) {
+	testCases := map[string]struct {
+		path     string
+		value    interface{}
+		expected string
+	}{
+		"keyfile":        {path: "auth.key", value: "keyfileSecret", expected: sensitiveValue},
+		"index key":      {path: "indexConfigs.key", value: []interface{}{[]interface{}{"name", "1"}}, expected: `[["name","1"]]`},
+		"nested keyfile": {path: "auth", value: map[string]interface{}{"key": "keyfileSecret", "keyfile": "/etc/keyfile"}, expected: `{"key":"(sensitive value)","keyfile":"/etc/keyfile"}`},
+		"nested index key": {
+			path:     "",
+			value:    map[string]interface{}{"indexConfigs": []interface{}{map[string]interface{}{"key": []interface{}{[]interface{}{"name", "1"}}}}},
+			expected: `{"indexConfigs":[{"key":[["name","1"]]}]}`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := renderValue(tc.path, tc.value); got != tc.expected {
+				t.Errorf("renderValue() = %s, expected %s", got, tc.expected)
+			}
+		})
+	}
+}
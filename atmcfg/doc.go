@@ -15,8 +15,35 @@
 /*
 Package atmcfg provides a set of helper methods to help you update the automation config.
 
-Usage
+# Usage
 
 	import "go.mongodb.org/ops-manager/atmcfg"
+
+# Steps
+
+Some changes must wait for every process to reach the goal state before going further, like
+SetTLSMode, DrainShard, RotateAgentPassword and UpgradePlan.Apply. They take the automation status
+and only make the next step once it shows the goal state of the config they're given was reached,
+leaving the config unchanged otherwise. They report whether the change is complete, so they're meant
+to be called in a loop with Update and WaitForGoalState until they return true:
+
+	for done := false; !done; {
+		_, err := atmcfg.Update(ctx, client.Automation, groupID, func(out *opsmngr.AutomationConfig) error {
+			status, _, err := client.Automation.GetStatus(ctx, groupID)
+			if err != nil {
+				return err
+			}
+			done, err = atmcfg.SetTLSMode(out, clusterName, "requireTLS", status)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if !done {
+			if _, err := atmcfg.WaitForGoalState(ctx, client.Automation, groupID, nil); err != nil {
+				return err
+			}
+		}
+	}
 */
 package atmcfg
@@ -0,0 +1,263 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.mongodb.org/ops-manager/search"
+)
+
+var (
+	// ErrReplicaSetNotFound means the config has no replica set with the given name.
+	ErrReplicaSetNotFound = errors.New("replica set not found")
+	// ErrMemberNotFound means the replica set has no member with the given name.
+	ErrMemberNotFound = errors.New("member not found")
+	// ErrUnsafeReconfig means the change would leave the replica set without a majority or with too many voters.
+	ErrUnsafeReconfig = errors.New("unsafe replica set reconfiguration")
+)
+
+// AddMember adds a member running on host and port to the replica set rsName.
+// The new process copies the version and the settings of an existing member, except its port and paths,
+// it's named after the replica set and gets the next free member _id.
+func AddMember(out *opsmngr.AutomationConfig, rsName, host string, port int, opts *MemberOptions) error {
+	if host == "" {
+		return errors.New("host must be set")
+	}
+	rs, err := replicaSet(out, rsName)
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &MemberOptions{}
+	}
+	template, err := memberProcess(out, rsName, rs.Members)
+	if err != nil {
+		return err
+	}
+
+	name := nextProcessName(out, rsName)
+	nextID := 0
+	for _, m := range rs.Members {
+		if m.ID >= nextID {
+			nextID = m.ID + 1
+		}
+	}
+
+	p := newProcess(name, host, port, template.Version, template.FeatureCompatibilityVersion, mongodProcessType)
+	p.AuthSchemaVersion = template.AuthSchemaVersion
+	// TLS, security, parameters or the storage engine are the same for every member,
+	// only the port and the paths are specific to the host
+	args, err := copyArgs26(&template.Args26)
+	if err != nil {
+		return err
+	}
+	args.NET.Port = p.Args26.NET.Port
+	if args.Replication == nil {
+		args.Replication = &opsmngr.Replication{}
+	}
+	args.Replication.ReplSetName = rsName
+	if args.SystemLog.Destination == "" {
+		args.SystemLog.Destination = p.Args26.SystemLog.Destination
+	}
+	p.Args26 = *args
+	dbPath := opts.DBPath
+	if dbPath == "" {
+		dbPath = defaultDataDir
+		if template.Args26.Storage != nil && template.Args26.Storage.DBPath != "" {
+			dbPath = path.Dir(template.Args26.Storage.DBPath)
+		}
+		dbPath = path.Join(dbPath, name)
+	}
+	if p.Args26.Storage == nil {
+		p.Args26.Storage = &opsmngr.Storage{}
+	}
+	p.Args26.Storage.DBPath = dbPath
+	p.Args26.SystemLog.Path = path.Join(dbPath, "mongodb.log")
+
+	members := append(append([]opsmngr.Member{}, rs.Members...), newMember(nextID, name, p.Version, opts))
+	if err := checkMembers(append(out.Processes, p), rsName, members); err != nil {
+		return err
+	}
+
+	out.Processes = append(out.Processes, p)
+	rs.Members = members
+	return nil
+}
+
+// RemoveMember removes the member processName and its process from the replica set rsName.
+// This won't shutdown the running process.
+func RemoveMember(out *opsmngr.AutomationConfig, rsName, processName string) error {
+	rs, err := replicaSet(out, rsName)
+	if err != nil {
+		return err
+	}
+	i, err := member(rs, processName)
+	if err != nil {
+		return err
+	}
+
+	members := append(append([]opsmngr.Member{}, rs.Members[:i]...), rs.Members[i+1:]...)
+	if err := checkMembers(out.Processes, rsName, members); err != nil {
+		return err
+	}
+
+	rs.Members = members
+	processes := make([]*opsmngr.Process, 0, len(out.Processes))
+	for _, p := range out.Processes {
+		if p.Name != processName {
+			processes = append(processes, p)
+		}
+	}
+	out.Processes = processes
+	return nil
+}
+
+// SetMemberOptions replaces the options of the member processName of the replica set rsName,
+// options not set in opts are reset to their default. opts.DBPath is ignored.
+func SetMemberOptions(out *opsmngr.AutomationConfig, rsName, processName string, opts *MemberOptions) error {
+	rs, err := replicaSet(out, rsName)
+	if err != nil {
+		return err
+	}
+	i, err := member(rs, processName)
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = &MemberOptions{}
+	}
+
+	version := ""
+	if j, found := search.Processes(out.Processes, func(p *opsmngr.Process) bool {
+		return p.Name == processName
+	}); found {
+		version = out.Processes[j].Version
+	}
+
+	members := append([]opsmngr.Member{}, rs.Members...)
+	setMemberOptions(&members[i], version, opts)
+	if err := checkMembers(out.Processes, rsName, members); err != nil {
+		return err
+	}
+
+	rs.Members = members
+	return nil
+}
+
+// checkMembers makes sure the replica set has at most seven voters,
+// and that the enabled voting members are a majority able to elect a primary.
+func checkMembers(processes []*opsmngr.Process, rsName string, members []opsmngr.Member) error {
+	disabled := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		disabled[p.Name] = p.Disabled
+	}
+
+	voters, up := 0, 0
+	electable := false
+	for _, m := range members {
+		if m.Votes == 0 {
+			continue
+		}
+		voters++
+		if disabled[m.Host] {
+			continue
+		}
+		up++
+		if !m.ArbiterOnly && m.Priority > 0 {
+			electable = true
+		}
+	}
+
+	switch {
+	case voters > maxVotingMembers:
+		return fmt.Errorf("%w: %s would have %d voting members, at most %d are allowed", ErrUnsafeReconfig, rsName, voters, maxVotingMembers)
+	case up*2 <= voters:
+		return fmt.Errorf("%w: %s would have %d of %d voting members enabled, no majority", ErrUnsafeReconfig, rsName, up, voters)
+	case !electable:
+		return fmt.Errorf("%w: %s would have no enabled member able to become primary", ErrUnsafeReconfig, rsName)
+	}
+	return nil
+}
+
+func replicaSet(out *opsmngr.AutomationConfig, name string) (*opsmngr.ReplicaSet, error) {
+	i, found := search.ReplicaSets(out.ReplicaSets, func(rs *opsmngr.ReplicaSet) bool {
+		return rs.ID == name
+	})
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrReplicaSetNotFound, name)
+	}
+	return out.ReplicaSets[i], nil
+}
+
+func member(rs *opsmngr.ReplicaSet, processName string) (int, error) {
+	i, found := search.Members(rs.Members, func(m opsmngr.Member) bool {
+		return m.Host == processName
+	})
+	if !found {
+		return 0, fmt.Errorf("%w: %s in %s", ErrMemberNotFound, processName, rs.ID)
+	}
+	return i, nil
+}
+
+// memberProcess returns the process of a data bearing member, used as a template for new members.
+func memberProcess(out *opsmngr.AutomationConfig, rsName string, members []opsmngr.Member) (*opsmngr.Process, error) {
+	var arbiter *opsmngr.Process
+	for _, m := range members {
+		i, found := search.Processes(out.Processes, func(p *opsmngr.Process) bool {
+			return p.Name == m.Host
+		})
+		if !found {
+			continue
+		}
+		if !m.ArbiterOnly {
+			return out.Processes[i], nil
+		}
+		arbiter = out.Processes[i]
+	}
+	if arbiter == nil {
+		return nil, fmt.Errorf("replica set %s has no process to copy the settings from", rsName)
+	}
+	return arbiter, nil
+}
+
+// nextProcessName returns the first free name of the form <rsName>_<n>.
+func nextProcessName(out *opsmngr.AutomationConfig, rsName string) string {
+	for n := 0; ; n++ {
+		name := fmt.Sprintf("%s_%d", rsName, n)
+		if _, found := search.Processes(out.Processes, func(p *opsmngr.Process) bool {
+			return p.Name == name
+		}); !found {
+			return name
+		}
+	}
+}
+
+// copyArgs26 returns a deep copy of the arguments of a process.
+func copyArgs26(in *opsmngr.Args26) (*opsmngr.Args26, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := new(opsmngr.Args26)
+	if err := json.Unmarshal(b, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
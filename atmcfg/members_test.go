@@ -0,0 +1,158 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func TestAddMember(t *testing.T) {
+	out := automationConfigWithOneReplicaSet(clusterName, false)
+	out.Auth.Disabled = true
+
+	delay := float64(3600)
+	if err := AddMember(out, clusterName, "host1", 0, &MemberOptions{Hidden: true, SecondaryDelaySecs: &delay}); err != nil {
+		t.Fatalf("AddMember() returned an error: %v", err)
+	}
+	if err := AddMember(out, clusterName, "host2", 0, nil); err != nil {
+		t.Fatalf("AddMember() returned an error: %v", err)
+	}
+	if err := Validate(out); err != nil {
+		t.Errorf("Validate() returned an error: %v", err)
+	}
+
+	members := out.ReplicaSets[0].Members
+	if len(members) != 3 || len(out.Processes) != 3 {
+		t.Fatalf("got %d members and %d processes", len(members), len(out.Processes))
+	}
+	m := members[1]
+	if m.ID != 1 || m.Host != clusterName+"_1" || m.Priority != 0 || m.SlaveDelay == nil || m.SecondaryDelaySecs != nil {
+		t.Errorf("unexpected member %+v", m)
+	}
+	p := out.Processes[1]
+	if p.Version != "4.2.2" || p.Args26.Replication.ReplSetName != clusterName || p.Args26.Storage.DBPath != "/data/db/"+clusterName+"_1" {
+		t.Errorf("expected the process to copy the existing members, got %+v", p)
+	}
+
+	if err := AddMember(out, "unknown", "host3", 0, nil); !errors.Is(err, ErrReplicaSetNotFound) {
+		t.Errorf("expected ErrReplicaSetNotFound, got %v", err)
+	}
+}
+
+func TestAddMember_copiesSettings(t *testing.T) {
+	out := automationConfigWithOneReplicaSet(clusterName, false)
+	template := out.Processes[0]
+	template.Args26.NET.TLS = &opsmngr.TLS{Mode: "requireTLS", CAFile: "/etc/ssl/ca.pem", CertificateKeyFile: "/etc/ssl/server.pem"}
+	template.Args26.Storage.Engine = "wiredTiger"
+	template.Args26.SetParameter = &map[string]interface{}{"authenticationMechanisms": "SCRAM-SHA-256"}
+
+	if err := AddMember(out, clusterName, "host1", 27018, nil); err != nil {
+		t.Fatalf("AddMember() returned an error: %v", err)
+	}
+	p := out.Processes[1]
+	if p.Args26.NET.Port != 27018 || p.Args26.Storage.DBPath != "/data/db/"+clusterName+"_1" ||
+		p.Args26.SystemLog.Path != "/data/db/"+clusterName+"_1/mongodb.log" || p.Args26.Replication.ReplSetName != clusterName {
+		t.Errorf("expected the host specific settings to be set, got %+v", p.Args26)
+	}
+	if diff := deep.Equal(p.Args26.NET.TLS, template.Args26.NET.TLS); diff != nil {
+		t.Errorf("expected the TLS settings to be copied: %v", diff)
+	}
+	if p.Args26.NET.TLS == template.Args26.NET.TLS {
+		t.Error("expected the TLS settings to be a copy")
+	}
+	if p.Args26.Storage.Engine != "wiredTiger" || p.Args26.SetParameter == nil {
+		t.Errorf("expected the storage engine and parameters to be copied, got %+v", p.Args26)
+	}
+	if template.Args26.NET.Port == 27018 || template.Args26.Storage.DBPath == p.Args26.Storage.DBPath {
+		t.Error("the existing member must not be modified")
+	}
+}
+
+func TestAddMember_tooManyVoters(t *testing.T) {
+	out := &opsmngr.AutomationConfig{}
+	b := NewReplicaSet("rs").WithVersion("6.0.5")
+	for _, host := range []string{"host0", "host1", "host2", "host3", "host4", "host5", "host6"} {
+		b.WithMember(host, 0, nil)
+	}
+	if err := b.AddTo(out); err != nil {
+		t.Fatalf("AddTo() returned an error: %v", err)
+	}
+
+	if err := AddMember(out, "rs", "host7", 0, nil); !errors.Is(err, ErrUnsafeReconfig) {
+		t.Errorf("expected ErrUnsafeReconfig, got %v", err)
+	}
+	if err := AddMember(out, "rs", "host7", 0, &MemberOptions{NonVoting: true}); err != nil {
+		t.Errorf("AddMember() returned an error: %v", err)
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	out := &opsmngr.AutomationConfig{}
+	err := NewReplicaSet("rs").WithVersion("6.0.5").
+		WithMember("host0", 0, nil).
+		WithMember("host1", 0, nil).
+		WithMember("host2", 0, nil).
+		AddTo(out)
+	if err != nil {
+		t.Fatalf("AddTo() returned an error: %v", err)
+	}
+	out.Processes[2].Disabled = true
+
+	// rs_1 is needed for the majority while rs_2 is shutdown
+	if err := RemoveMember(out, "rs", "rs_1"); !errors.Is(err, ErrUnsafeReconfig) {
+		t.Errorf("expected ErrUnsafeReconfig, got %v", err)
+	}
+	if err := RemoveMember(out, "rs", "rs_2"); err != nil {
+		t.Fatalf("RemoveMember() returned an error: %v", err)
+	}
+	if len(out.ReplicaSets[0].Members) != 2 || len(out.Processes) != 2 {
+		t.Errorf("expected the member and its process to be removed")
+	}
+	if err := RemoveMember(out, "rs", "rs_2"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected ErrMemberNotFound, got %v", err)
+	}
+}
+
+func TestSetMemberOptions(t *testing.T) {
+	out := &opsmngr.AutomationConfig{}
+	err := NewReplicaSet("rs").WithVersion("6.0.5").
+		WithMember("host0", 0, nil).
+		WithMember("host1", 0, nil).
+		AddTo(out)
+	if err != nil {
+		t.Fatalf("AddTo() returned an error: %v", err)
+	}
+
+	delay := float64(60)
+	if err := SetMemberOptions(out, "rs", "rs_1", &MemberOptions{Hidden: true, SecondaryDelaySecs: &delay, Tags: map[string]string{"dc": "east"}}); err != nil {
+		t.Fatalf("SetMemberOptions() returned an error: %v", err)
+	}
+	m := out.ReplicaSets[0].Members[1]
+	if !m.Hidden || m.Priority != 0 || m.SecondaryDelaySecs == nil || m.SlaveDelay != nil || (*m.Tags)["dc"] != "east" {
+		t.Errorf("unexpected member %+v", m)
+	}
+
+	// the only electable member can't become an arbiter
+	if err := SetMemberOptions(out, "rs", "rs_0", &MemberOptions{ArbiterOnly: true}); !errors.Is(err, ErrUnsafeReconfig) {
+		t.Errorf("expected ErrUnsafeReconfig, got %v", err)
+	}
+	if out.ReplicaSets[0].Members[0].ArbiterOnly {
+		t.Error("the member must not be changed when the change is refused")
+	}
+}
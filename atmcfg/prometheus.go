@@ -0,0 +1,224 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+const (
+	prometheusSchemeHTTP    = "http"
+	prometheusSchemeHTTPS   = "https"
+	prometheusMode          = "opsManager"
+	prometheusListenAddress = "0.0.0.0:9216"
+	prometheusMetricsPath   = "/metrics"
+	// Ops Manager stores the password of the endpoint as a PBKDF2-HMAC-SHA256 hash
+	// of 256 iterations with a 16 bytes salt, like the MongoDB Kubernetes operators do.
+	prometheusSaltLength      = 16
+	prometheusHashIterations  = 256
+	prometheusMaxPort         = 65535
+	prometheusDefaultJobName  = "mongodb"
+	prometheusYAMLIndentation = "  "
+)
+
+// ErrPrometheusNotConfigured means the config has no Prometheus settings.
+var ErrPrometheusNotConfigured = errors.New("prometheus not configured")
+
+// PrometheusOptions are the settings of the Prometheus endpoint of the automation agents.
+type PrometheusOptions struct {
+	Username       string
+	Password       string // Password is the cleartext password, only its hash is stored in the config
+	Scheme         string // Scheme is http or https, defaults to https
+	TLSPemPath     string // TLSPemPath is the certificate of the endpoint, required with https
+	TLSPemPassword string
+	ListenAddress  string // ListenAddress is the host:port the endpoint listens on, defaults to 0.0.0.0:9216
+	MetricsPath    string // MetricsPath defaults to /metrics
+}
+
+// EnablePrometheus enables the Prometheus endpoint of the automation agents,
+// the password is stored as a salted PBKDF2-HMAC-SHA256 hash.
+func EnablePrometheus(out *opsmngr.AutomationConfig, opts *PrometheusOptions) error {
+	if opts == nil {
+		return errors.New("prometheus options must be set")
+	}
+	o := *opts
+	if o.Scheme == "" {
+		o.Scheme = prometheusSchemeHTTPS
+	}
+	if o.ListenAddress == "" {
+		o.ListenAddress = prometheusListenAddress
+	}
+	if o.MetricsPath == "" {
+		o.MetricsPath = prometheusMetricsPath
+	}
+	if err := o.validate(); err != nil {
+		return err
+	}
+
+	hash, salt, err := prometheusPasswordHash(o.Password)
+	if err != nil {
+		return err
+	}
+	p := out.Prometheus
+	if p == nil {
+		p = &opsmngr.Prometheus{Mode: prometheusMode}
+	}
+	p.Enabled = true
+	p.Username = o.Username
+	p.PasswordHash = hash
+	p.PasswordSalt = salt
+	p.Scheme = o.Scheme
+	p.TLSPemPath = o.TLSPemPath
+	p.TLSPemPassword = o.TLSPemPassword
+	p.ListenAddress = o.ListenAddress
+	p.MetricsPath = o.MetricsPath
+	out.Prometheus = p
+	return nil
+}
+
+// DisablePrometheus disables the Prometheus endpoint, keeping its settings.
+func DisablePrometheus(out *opsmngr.AutomationConfig) error {
+	if out.Prometheus == nil {
+		return ErrPrometheusNotConfigured
+	}
+	out.Prometheus.Enabled = false
+	return nil
+}
+
+func (o *PrometheusOptions) validate() error {
+	if o.Username == "" || o.Password == "" {
+		return errors.New("prometheus username and password must be set")
+	}
+	switch o.Scheme {
+	case prometheusSchemeHTTPS:
+		if o.TLSPemPath == "" {
+			return fmt.Errorf("prometheus TLS PEM path must be set with %s", o.Scheme)
+		}
+	case prometheusSchemeHTTP:
+		if o.TLSPemPath != "" || o.TLSPemPassword != "" {
+			return fmt.Errorf("prometheus TLS PEM settings require %s", prometheusSchemeHTTPS)
+		}
+	default:
+		return fmt.Errorf("invalid prometheus scheme %s, expected %s or %s", o.Scheme, prometheusSchemeHTTP, prometheusSchemeHTTPS)
+	}
+	if _, err := prometheusPort(o.ListenAddress); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(o.MetricsPath, "/") {
+		return fmt.Errorf("invalid prometheus metrics path %s, it must start with /", o.MetricsPath)
+	}
+	return nil
+}
+
+// prometheusPort returns the port of a host:port listen address.
+func prometheusPort(address string) (string, error) {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid prometheus listen address %s: %w", address, err)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > prometheusMaxPort {
+		return "", fmt.Errorf("invalid prometheus listen address %s: invalid port", address)
+	}
+	return port, nil
+}
+
+// prometheusPasswordHash returns the base64 encoded hash and random salt of password.
+func prometheusPasswordHash(password string) (hash, salt string, err error) {
+	b := make([]byte, prometheusSaltLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	if hash, err = hashPrometheusPassword(password, b); err != nil {
+		return "", "", err
+	}
+	return hash, base64.StdEncoding.EncodeToString(b), nil
+}
+
+// hashPrometheusPassword returns the base64 encoded hash of password with salt.
+func hashPrometheusPassword(password string, salt []byte) (string, error) {
+	h, err := pbkdf2Block(sha256.New, []byte(password), salt, prometheusHashIterations)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(h), nil
+}
+
+// PrometheusScrapeConfig returns the Prometheus scrape_configs YAML to scrape the automation agents
+// of every host of the config. The config only stores the hash of the password, so it must be given,
+// jobName defaults to mongodb. With https, the certificates are verified with the CA file of the agents,
+// its path may have to be adapted to the Prometheus server.
+func PrometheusScrapeConfig(out *opsmngr.AutomationConfig, jobName, password string) (string, error) {
+	p := out.Prometheus
+	if p == nil || !p.Enabled {
+		return "", ErrPrometheusNotConfigured
+	}
+	port, err := prometheusPort(p.ListenAddress)
+	if err != nil {
+		return "", err
+	}
+	if jobName == "" {
+		jobName = prometheusDefaultJobName
+	}
+
+	var targets []string
+	seen := map[string]bool{}
+	for _, proc := range out.Processes {
+		if proc.Hostname == "" || seen[proc.Hostname] {
+			continue
+		}
+		seen[proc.Hostname] = true
+		targets = append(targets, net.JoinHostPort(proc.Hostname, port))
+	}
+	if len(targets) == 0 {
+		return "", errors.New("no hosts to scrape")
+	}
+
+	var b strings.Builder
+	line := func(indent int, format string, a ...interface{}) {
+		b.WriteString(strings.Repeat(prometheusYAMLIndentation, indent))
+		fmt.Fprintf(&b, format, a...)
+		b.WriteString("\n")
+	}
+	line(0, "scrape_configs:")
+	line(1, "- job_name: %s", strconv.Quote(jobName))
+	line(2, "scheme: %s", p.Scheme)
+	line(2, "metrics_path: %s", strconv.Quote(p.MetricsPath))
+	if p.Scheme == prometheusSchemeHTTPS {
+		line(2, "tls_config:")
+		if out.TLS != nil && out.TLS.CAFilePath != "" {
+			line(3, "ca_file: %s", strconv.Quote(out.TLS.CAFilePath))
+		} else {
+			line(3, "insecure_skip_verify: false")
+		}
+	}
+	line(2, "basic_auth:")
+	line(3, "username: %s", strconv.Quote(p.Username))
+	line(3, "password: %s", strconv.Quote(password))
+	line(2, "static_configs:")
+	line(3, "- targets:")
+	for _, t := range targets {
+		line(5, "- %s", strconv.Quote(t))
+	}
+	return b.String(), nil
+}
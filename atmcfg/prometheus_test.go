@@ -0,0 +1,171 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestEnablePrometheus(t *testing.T) {
+	t.Run("https", func(t *testing.T) {
+		config := automationConfigWithOneReplicaSet(clusterName, false)
+		err := EnablePrometheus(config, &PrometheusOptions{
+			Username:   "prometheus",
+			Password:   "password",
+			TLSPemPath: "/etc/prometheus.pem",
+		})
+		if err != nil {
+			t.Fatalf("EnablePrometheus() unexpected error: %v", err)
+		}
+		p := config.Prometheus
+		if !p.Enabled || p.Scheme != "https" || p.ListenAddress != "0.0.0.0:9216" || p.MetricsPath != "/metrics" || p.Mode != "opsManager" {
+			t.Errorf("unexpected settings %+v", p)
+		}
+
+		salt, err := base64.StdEncoding.DecodeString(p.PasswordSalt)
+		if err != nil || len(salt) != prometheusSaltLength {
+			t.Fatalf("invalid salt %s", p.PasswordSalt)
+		}
+		if hash, _ := hashPrometheusPassword("password", salt); p.PasswordHash != hash {
+			t.Errorf("unexpected password hash %s", p.PasswordHash)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for name, opts := range map[string]*PrometheusOptions{
+			"no password":       {Username: "prometheus", Scheme: "http"},
+			"https without pem": {Username: "prometheus", Password: "password"},
+			"http with pem":     {Username: "prometheus", Password: "password", Scheme: "http", TLSPemPath: "/etc/prometheus.pem"},
+			"invalid scheme":    {Username: "prometheus", Password: "password", Scheme: "ftp"},
+			"invalid address":   {Username: "prometheus", Password: "password", Scheme: "http", ListenAddress: "0.0.0.0"},
+			"invalid port":      {Username: "prometheus", Password: "password", Scheme: "http", ListenAddress: "0.0.0.0:99999"},
+			"invalid path":      {Username: "prometheus", Password: "password", Scheme: "http", MetricsPath: "metrics"},
+		} {
+			opts := opts
+			t.Run(name, func(t *testing.T) {
+				config := automationConfigWithOneReplicaSet(clusterName, false)
+				if err := EnablePrometheus(config, opts); err == nil {
+					t.Fatal("EnablePrometheus() expected an error but got none")
+				}
+				if config.Prometheus != nil {
+					t.Error("config changed on error")
+				}
+			})
+		}
+	})
+}
+
+func TestHashPrometheusPassword(t *testing.T) {
+	// PBKDF2-HMAC-SHA256 of "password" with 256 iterations and the salt 0x00 to 0x0f,
+	// computed independently with Python's hashlib.pbkdf2_hmac
+	const (
+		salt = "AAECAwQFBgcICQoLDA0ODw=="
+		want = "nSSm9aa0YKBGNpfFBcCD7IeJ8M9VBw2UoGOWwjxkERA="
+	)
+	b, _ := base64.StdEncoding.DecodeString(salt)
+	got, err := hashPrometheusPassword("password", b)
+	if err != nil {
+		t.Fatalf("hashPrometheusPassword() unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("hashPrometheusPassword() = %s, want %s", got, want)
+	}
+}
+
+func TestDisablePrometheus(t *testing.T) {
+	config := automationConfigWithOneReplicaSet(clusterName, false)
+	if err := DisablePrometheus(config); !errors.Is(err, ErrPrometheusNotConfigured) {
+		t.Fatalf("DisablePrometheus() expected ErrPrometheusNotConfigured, got %v", err)
+	}
+	if err := EnablePrometheus(config, &PrometheusOptions{Username: "prometheus", Password: "password", Scheme: "http"}); err != nil {
+		t.Fatalf("EnablePrometheus() unexpected error: %v", err)
+	}
+	if err := DisablePrometheus(config); err != nil {
+		t.Fatalf("DisablePrometheus() unexpected error: %v", err)
+	}
+	if config.Prometheus.Enabled || config.Prometheus.Username != "prometheus" {
+		t.Errorf("unexpected settings %+v", config.Prometheus)
+	}
+}
+
+func TestPrometheusScrapeConfig(t *testing.T) {
+	config := automationConfigWithOneShardedCluster(clusterName, false)
+	if _, err := PrometheusScrapeConfig(config, "", "password"); !errors.Is(err, ErrPrometheusNotConfigured) {
+		t.Fatalf("PrometheusScrapeConfig() expected ErrPrometheusNotConfigured, got %v", err)
+	}
+	err := EnablePrometheus(config, &PrometheusOptions{
+		Username:      "prometheus",
+		Password:      "password",
+		Scheme:        "http",
+		ListenAddress: "0.0.0.0:9100",
+	})
+	if err != nil {
+		t.Fatalf("EnablePrometheus() unexpected error: %v", err)
+	}
+	config.Processes[1].Hostname = "host1"
+
+	got, err := PrometheusScrapeConfig(config, "", "password")
+	if err != nil {
+		t.Fatalf("PrometheusScrapeConfig() unexpected error: %v", err)
+	}
+	const want = `scrape_configs:
+  - job_name: "mongodb"
+    scheme: http
+    metrics_path: "/metrics"
+    basic_auth:
+      username: "prometheus"
+      password: "password"
+    static_configs:
+      - targets:
+          - "host0:9100"
+          - "host1:9100"
+`
+	if got != want {
+		t.Errorf("PrometheusScrapeConfig() got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPrometheusScrapeConfig_https(t *testing.T) {
+	config := automationConfigWithOneReplicaSet(clusterName, false)
+	err := EnablePrometheus(config, &PrometheusOptions{Username: "prometheus", Password: "password", TLSPemPath: "/etc/prometheus.pem"})
+	if err != nil {
+		t.Fatalf("EnablePrometheus() unexpected error: %v", err)
+	}
+	if err := SetAgentTLS(config, "/etc/ssl/ca.pem", "OPTIONAL"); err != nil {
+		t.Fatalf("SetAgentTLS() unexpected error: %v", err)
+	}
+
+	got, err := PrometheusScrapeConfig(config, "agents", "password")
+	if err != nil {
+		t.Fatalf("PrometheusScrapeConfig() unexpected error: %v", err)
+	}
+	const want = `scrape_configs:
+  - job_name: "agents"
+    scheme: https
+    metrics_path: "/metrics"
+    tls_config:
+      ca_file: "/etc/ssl/ca.pem"
+    basic_auth:
+      username: "prometheus"
+      password: "password"
+    static_configs:
+      - targets:
+          - "host0:9216"
+`
+	if got != want {
+		t.Errorf("PrometheusScrapeConfig() got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.mongodb.org/ops-manager/search"
+)
+
+const (
+	defaultDataDir    = "/data"
+	protocolVersion   = "1"
+	mongodProcessType = "mongod"
+
+	secondaryDelayMinVersion = 5
+)
+
+// ErrAlreadyExists means a process or replica set with the same name is already in the config.
+var ErrAlreadyExists = errors.New("already exists")
+
+// MemberOptions are the options of a replica set member.
+type MemberOptions struct {
+	ArbiterOnly        bool
+	Hidden             bool
+	NonVoting          bool
+	Priority           *float64 // Priority defaults to 1, or 0 for arbiters, hidden and non-voting members
+	SecondaryDelaySecs *float64 // SecondaryDelaySecs is set as slaveDelay before MongoDB 5.0
+	Tags               map[string]string
+	DBPath             string // DBPath defaults to <data dir>/<process name>
+}
+
+type memberSpec struct {
+	host string
+	port int
+	opts MemberOptions
+}
+
+// ReplicaSetBuilder builds the processes and replica set entries of a new replica set.
+type ReplicaSetBuilder struct {
+	name        string
+	version     string
+	fcv         string
+	dataDir     string
+	clusterRole string
+	members     []memberSpec
+}
+
+// NewReplicaSet returns a builder for a replica set with the given name.
+func NewReplicaSet(name string) *ReplicaSetBuilder {
+	return &ReplicaSetBuilder{name: name, dataDir: defaultDataDir}
+}
+
+// WithVersion sets the MongoDB version of the members,
+// the feature compatibility version defaults to the major and minor version.
+func (b *ReplicaSetBuilder) WithVersion(version string) *ReplicaSetBuilder {
+	b.version = version
+	return b
+}
+
+// WithFeatureCompatibilityVersion sets the feature compatibility version of the members.
+func (b *ReplicaSetBuilder) WithFeatureCompatibilityVersion(fcv string) *ReplicaSetBuilder {
+	b.fcv = fcv
+	return b
+}
+
+// WithDataDir sets the directory containing the data directory of each member, defaults to /data.
+func (b *ReplicaSetBuilder) WithDataDir(dir string) *ReplicaSetBuilder {
+	b.dataDir = dir
+	return b
+}
+
+// WithMember adds a member running on host and port, opts can be nil.
+func (b *ReplicaSetBuilder) WithMember(host string, port int, opts *MemberOptions) *ReplicaSetBuilder {
+	m := memberSpec{host: host, port: port}
+	if opts != nil {
+		m.opts = *opts
+	}
+	b.members = append(b.members, m)
+	return b
+}
+
+// Build returns the processes and the replica set.
+func (b *ReplicaSetBuilder) Build() ([]*opsmngr.Process, *opsmngr.ReplicaSet, error) {
+	if b.name == "" {
+		return nil, nil, errors.New("replica set name must be set")
+	}
+	if b.version == "" {
+		return nil, nil, fmt.Errorf("replica set %s: version must be set", b.name)
+	}
+	if len(b.members) == 0 {
+		return nil, nil, fmt.Errorf("replica set %s: at least one member must be added", b.name)
+	}
+
+	processes := make([]*opsmngr.Process, len(b.members))
+	rs := &opsmngr.ReplicaSet{
+		ID:              b.name,
+		ProtocolVersion: protocolVersion,
+		Members:         make([]opsmngr.Member, len(b.members)),
+	}
+	for i, m := range b.members {
+		if m.host == "" {
+			return nil, nil, fmt.Errorf("replica set %s: member %d: host must be set", b.name, i)
+		}
+		name := fmt.Sprintf("%s_%d", b.name, i)
+		processes[i] = b.process(name, m)
+		rs.Members[i] = newMember(i, name, b.version, &m.opts)
+	}
+
+	return processes, rs, nil
+}
+
+// AddTo builds the replica set and appends it to out.
+func (b *ReplicaSetBuilder) AddTo(out *opsmngr.AutomationConfig) error {
+	processes, rs, err := b.Build()
+	if err != nil {
+		return err
+	}
+	if err := checkNotExists(out, processes, rs); err != nil {
+		return err
+	}
+	out.Processes = append(out.Processes, processes...)
+	out.ReplicaSets = append(out.ReplicaSets, rs)
+	return nil
+}
+
+func (b *ReplicaSetBuilder) process(name string, m memberSpec) *opsmngr.Process {
+	dbPath := m.opts.DBPath
+	if dbPath == "" {
+		dbPath = path.Join(b.dataDir, name)
+	}
+	p := newProcess(name, m.host, m.port, b.version, b.fcv, mongodProcessType)
+	p.Args26.Replication = &opsmngr.Replication{ReplSetName: b.name}
+	p.Args26.Storage = &opsmngr.Storage{DBPath: dbPath}
+	p.Args26.SystemLog.Path = path.Join(dbPath, "mongodb.log")
+	if b.clusterRole != "" {
+		p.Args26.Sharding = &opsmngr.Sharding{ClusterRole: b.clusterRole}
+	}
+	return p
+}
+
+func newProcess(name, host string, port int, version, fcv, processType string) *opsmngr.Process {
+	if port == 0 {
+		port = defaultMongoPort
+	}
+	if fcv == "" {
+		fcv = featureCompatibilityVersion(version)
+	}
+	return &opsmngr.Process{
+		Args26: opsmngr.Args26{
+			NET: opsmngr.Net{
+				Port: port,
+			},
+			SystemLog: opsmngr.SystemLog{
+				Destination: "file",
+			},
+		},
+		AuthSchemaVersion:           authSchemaVersion,
+		Name:                        name,
+		FeatureCompatibilityVersion: fcv,
+		Hostname:                    host,
+		LogRotate: &opsmngr.LogRotate{
+			SizeThresholdMB:  defaultSizeThresholdMB,
+			TimeThresholdHrs: defaultTimeThresholdHrs,
+		},
+		ProcessType: processType,
+		Version:     version,
+	}
+}
+
+func newMember(id int, host, version string, opts *MemberOptions) opsmngr.Member {
+	m := opsmngr.Member{
+		ID:           id,
+		BuildIndexes: true,
+		Host:         host,
+	}
+	setMemberOptions(&m, version, opts)
+	return m
+}
+
+// setMemberOptions sets every option of m from opts.
+func setMemberOptions(m *opsmngr.Member, version string, opts *MemberOptions) {
+	m.ArbiterOnly = opts.ArbiterOnly
+	m.Hidden = opts.Hidden
+	m.Priority = 1
+	m.Votes = 1
+	if opts.NonVoting {
+		m.Votes = 0
+	}
+	if opts.ArbiterOnly || opts.Hidden || opts.NonVoting {
+		m.Priority = 0
+	}
+	if opts.Priority != nil {
+		m.Priority = *opts.Priority
+	}
+	m.Tags = nil
+	if len(opts.Tags) > 0 {
+		tags := make(map[string]string, len(opts.Tags))
+		for k, v := range opts.Tags {
+			tags[k] = v
+		}
+		m.Tags = &tags
+	}
+
+	// slaveDelay was renamed to secondaryDelaySecs in MongoDB 5.0
+	m.SlaveDelay, m.SecondaryDelaySecs = nil, nil
+	if opts.SecondaryDelaySecs != nil {
+		delay := *opts.SecondaryDelaySecs
+		if versionBefore(version, secondaryDelayMinVersion) {
+			m.SlaveDelay = &delay
+		} else {
+			m.SecondaryDelaySecs = &delay
+		}
+	}
+}
+
+// featureCompatibilityVersion returns the major and minor parts of a MongoDB version, i.e. 4.2 for 4.2.2.
+func featureCompatibilityVersion(version string) string {
+	major, rest, found := strings.Cut(version, ".")
+	if !found {
+		return version
+	}
+	minor, _, _ := strings.Cut(rest, ".")
+	return major + "." + minor
+}
+
+// versionBefore reports whether the MongoDB version v is older than the major version,
+// unknown versions are considered recent.
+func versionBefore(v string, major int) bool {
+	m, _, _ := strings.Cut(v, ".")
+	n, err := strconv.Atoi(m)
+	return err == nil && n < major
+}
+
+func checkNotExists(out *opsmngr.AutomationConfig, processes []*opsmngr.Process, rs *opsmngr.ReplicaSet) error {
+	if rs != nil {
+		if _, found := search.ReplicaSets(out.ReplicaSets, func(r *opsmngr.ReplicaSet) bool {
+			return r.ID == rs.ID
+		}); found {
+			return fmt.Errorf("replica set %s %w", rs.ID, ErrAlreadyExists)
+		}
+	}
+	for _, p := range processes {
+		if _, found := search.Processes(out.Processes, func(q *opsmngr.Process) bool {
+			return q.Name == p.Name
+		}); found {
+			return fmt.Errorf("process %s %w", p.Name, ErrAlreadyExists)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func TestReplicaSetBuilder(t *testing.T) {
+	t.Run("matches the existing layout", func(t *testing.T) {
+		out := &opsmngr.AutomationConfig{Auth: opsmngr.Auth{Disabled: true}}
+		err := NewReplicaSet(clusterName).
+			WithVersion("4.2.2").
+			WithDataDir("/data/db").
+			WithMember("host0", defaultMongoPort, &MemberOptions{DBPath: "/data/db/"}).
+			AddTo(out)
+		if err != nil {
+			t.Fatalf("AddTo() returned an error: %v", err)
+		}
+
+		expected := automationConfigWithOneReplicaSet(clusterName, false)
+		expected.Auth.Disabled = true
+		expected.ReplicaSets[0].Members[0].SlaveDelay = nil
+		if diff := deep.Equal(out, expected); diff != nil {
+			t.Error(diff)
+		}
+	})
+	t.Run("members", func(t *testing.T) {
+		out := &opsmngr.AutomationConfig{Auth: opsmngr.Auth{Disabled: true}}
+		err := NewReplicaSet("rs").
+			WithVersion("6.0.5").
+			WithMember("host0", 0, nil).
+			WithMember("host1", 0, &MemberOptions{Hidden: true, Tags: map[string]string{"use": "reporting"}}).
+			WithMember("host2", 0, &MemberOptions{ArbiterOnly: true}).
+			AddTo(out)
+		if err != nil {
+			t.Fatalf("AddTo() returned an error: %v", err)
+		}
+		if err := Validate(out); err != nil {
+			t.Errorf("Validate() returned an error: %v", err)
+		}
+
+		for i, m := range out.ReplicaSets[0].Members {
+			if m.ID != i || m.Host != out.Processes[i].Name {
+				t.Errorf("member %d: got _id %d and host %s", i, m.ID, m.Host)
+			}
+		}
+		if p := out.Processes[1]; p.Args26.Storage.DBPath != "/data/rs_1" || p.Args26.SystemLog.Path != "/data/rs_1/mongodb.log" || p.FeatureCompatibilityVersion != "6.0" {
+			t.Errorf("unexpected defaults: %+v", p)
+		}
+		if m := out.ReplicaSets[0].Members[1]; m.Priority != 0 || m.Votes != 1 || (*m.Tags)["use"] != "reporting" {
+			t.Errorf("unexpected hidden member: %+v", m)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		if err := NewReplicaSet("rs").WithMember("host0", 0, nil).AddTo(&opsmngr.AutomationConfig{}); err == nil {
+			t.Error("expected an error without version")
+		}
+		if err := NewReplicaSet("rs").WithVersion("6.0.5").AddTo(&opsmngr.AutomationConfig{}); err == nil {
+			t.Error("expected an error without members")
+		}
+		out := automationConfigWithOneReplicaSet(clusterName, false)
+		if err := NewReplicaSet(clusterName).WithVersion("6.0.5").WithMember("host1", 0, nil).AddTo(out); !errors.Is(err, ErrAlreadyExists) {
+			t.Errorf("expected ErrAlreadyExists, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.mongodb.org/ops-manager/search"
+)
+
+const adminDatabase = "admin"
+
+// builtInRoles are the MongoDB built-in roles, mapped to whether they only exist in the admin database.
+var builtInRoles = map[string]bool{
+	"read":                  false,
+	"readWrite":             false,
+	"dbAdmin":               false,
+	"dbOwner":               false,
+	"userAdmin":             false,
+	"enableSharding":        false,
+	"clusterAdmin":          true,
+	"clusterManager":        true,
+	"clusterMonitor":        true,
+	"hostManager":           true,
+	"backup":                true,
+	"restore":               true,
+	"readAnyDatabase":       true,
+	"readWriteAnyDatabase":  true,
+	"userAdminAnyDatabase":  true,
+	"dbAdminAnyDatabase":    true,
+	"root":                  true,
+	"directShardOperations": true,
+	"__system":              true,
+}
+
+var (
+	// ErrRoleNotFound means a role reference matches neither a built-in nor a custom role.
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrRoleInUse means a custom role can't be removed while users or other roles inherit from it.
+	ErrRoleInUse = errors.New("role in use")
+)
+
+// AddRole adds the custom role r to the config, the roles r inherits from must exist.
+func AddRole(out *opsmngr.AutomationConfig, r *opsmngr.CustomRole) error {
+	if err := checkRole(r); err != nil {
+		return err
+	}
+	if _, found := customRole(out, r.Role, r.Database); found {
+		return fmt.Errorf("role %s %w", qualifiedName(r.Role, r.Database), ErrAlreadyExists)
+	}
+	roles := append(append([]*opsmngr.CustomRole{}, out.Roles...), r)
+	if err := checkInheritedRoles(roles, r); err != nil {
+		return err
+	}
+	out.Roles = roles
+	return nil
+}
+
+// UpdateRole replaces the custom role with the name and database of r.
+func UpdateRole(out *opsmngr.AutomationConfig, r *opsmngr.CustomRole) error {
+	if err := checkRole(r); err != nil {
+		return err
+	}
+	i, found := customRole(out, r.Role, r.Database)
+	if !found {
+		return fmt.Errorf("%w: %s", ErrRoleNotFound, qualifiedName(r.Role, r.Database))
+	}
+	roles := append([]*opsmngr.CustomRole{}, out.Roles...)
+	roles[i] = r
+	if err := checkInheritedRoles(roles, r); err != nil {
+		return err
+	}
+	out.Roles = roles
+	return nil
+}
+
+// RemoveRole removes the custom role from the config,
+// it fails if a user or another custom role still references it.
+func RemoveRole(out *opsmngr.AutomationConfig, role, database string) error {
+	i, found := customRole(out, role, database)
+	if !found {
+		return fmt.Errorf("%w: %s", ErrRoleNotFound, qualifiedName(role, database))
+	}
+	for _, u := range out.Auth.UsersWanted {
+		if hasRole(u.Roles, role, database) {
+			return fmt.Errorf("%w: %s is granted to %s", ErrRoleInUse, qualifiedName(role, database), qualifiedName(u.Username, u.Database))
+		}
+	}
+	for _, r := range out.Roles {
+		if hasRole(r.Roles, role, database) {
+			return fmt.Errorf("%w: %s is inherited by %s", ErrRoleInUse, qualifiedName(role, database), qualifiedName(r.Role, r.Database))
+		}
+	}
+	out.Roles = append(out.Roles[:i], out.Roles[i+1:]...)
+	return nil
+}
+
+// checkRole checks the fields of a custom role.
+func checkRole(r *opsmngr.CustomRole) error {
+	if r == nil || r.Role == "" || r.Database == "" {
+		return errors.New("role name and database must be set")
+	}
+	if _, ok := builtInRoles[r.Role]; ok {
+		return fmt.Errorf("role %s has the name of a built-in role", qualifiedName(r.Role, r.Database))
+	}
+	if len(r.Privileges) == 0 && len(r.Roles) == 0 {
+		return fmt.Errorf("role %s must have privileges or inherit from other roles", qualifiedName(r.Role, r.Database))
+	}
+	for i, p := range r.Privileges {
+		if len(p.Actions) == 0 {
+			return fmt.Errorf("role %s: privilege %d: actions must be set", qualifiedName(r.Role, r.Database), i)
+		}
+		isCluster := p.Resource.Cluster != nil && *p.Resource.Cluster
+		if isCluster == (p.Resource.Database != nil || p.Resource.Collection != nil) {
+			return fmt.Errorf("role %s: privilege %d: resource must be either the cluster or a database and collection", qualifiedName(r.Role, r.Database), i)
+		}
+	}
+	return nil
+}
+
+// checkInheritedRoles makes sure the roles r inherits from exist and don't inherit from r.
+func checkInheritedRoles(roles []*opsmngr.CustomRole, r *opsmngr.CustomRole) error {
+	visited := map[string]bool{}
+	var visit func(inherited []*opsmngr.Role) error
+	visit = func(inherited []*opsmngr.Role) error {
+		for _, ref := range inherited {
+			if ref.Role == r.Role && ref.Database == r.Database {
+				return fmt.Errorf("role %s can't inherit from itself", qualifiedName(r.Role, r.Database))
+			}
+			name := qualifiedName(ref.Role, ref.Database)
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			if isBuiltInRole(ref) {
+				continue
+			}
+			i, found := search.CustomRoles(roles, func(c *opsmngr.CustomRole) bool {
+				return c.Role == ref.Role && c.Database == ref.Database
+			})
+			if !found {
+				return fmt.Errorf("%w: %s inherited by %s", ErrRoleNotFound, name, qualifiedName(r.Role, r.Database))
+			}
+			if err := visit(roles[i].Roles); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit(r.Roles)
+}
+
+// resolveRole reports whether ref is a built-in role or one of the custom roles.
+func resolveRole(roles []*opsmngr.CustomRole, ref *opsmngr.Role) bool {
+	if isBuiltInRole(ref) {
+		return true
+	}
+	_, found := search.CustomRoles(roles, func(c *opsmngr.CustomRole) bool {
+		return c.Role == ref.Role && c.Database == ref.Database
+	})
+	return found
+}
+
+func isBuiltInRole(ref *opsmngr.Role) bool {
+	adminOnly, ok := builtInRoles[ref.Role]
+	return ok && (!adminOnly || ref.Database == adminDatabase)
+}
+
+func customRole(out *opsmngr.AutomationConfig, role, database string) (int, bool) {
+	return search.CustomRoles(out.Roles, func(c *opsmngr.CustomRole) bool {
+		return c.Role == role && c.Database == database
+	})
+}
+
+func hasRole(roles []*opsmngr.Role, role, database string) bool {
+	for _, r := range roles {
+		if r.Role == role && r.Database == database {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedName returns the name of a user or role qualified by its database, i.e. name@database.
+func qualifiedName(name, database string) string {
+	return name + "@" + database
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func testRole(name string, inherits ...*opsmngr.Role) *opsmngr.CustomRole {
+	db := "test"
+	return &opsmngr.CustomRole{
+		Role:     name,
+		Database: "test",
+		Privileges: []*opsmngr.Privilege{
+			{
+				Resource: opsmngr.Resource{Database: &db, Collection: new(string)},
+				Actions:  []string{"find", "insert"},
+			},
+		},
+		Roles: inherits,
+	}
+}
+
+func TestAddRole(t *testing.T) {
+	t.Run("add", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		if err := AddRole(config, testRole("test", &opsmngr.Role{Role: "read", Database: "test"})); err != nil {
+			t.Fatalf("AddRole() unexpected error: %v", err)
+		}
+		if len(config.Roles) != 1 {
+			t.Fatalf("expected 1 role, got %d", len(config.Roles))
+		}
+		if err := Validate(config); err != nil {
+			t.Errorf("Validate() returned an error: %v", err)
+		}
+	})
+	t.Run("already exists", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		_ = AddRole(config, testRole("test"))
+		if err := AddRole(config, testRole("test")); !errors.Is(err, ErrAlreadyExists) {
+			t.Fatalf("AddRole() expected ErrAlreadyExists, got %v", err)
+		}
+	})
+	t.Run("unknown inherited role", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		if err := AddRole(config, testRole("test", &opsmngr.Role{Role: "other", Database: "test"})); !errors.Is(err, ErrRoleNotFound) {
+			t.Fatalf("AddRole() expected ErrRoleNotFound, got %v", err)
+		}
+	})
+	t.Run("admin only built-in role", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		if err := AddRole(config, testRole("test", &opsmngr.Role{Role: "clusterMonitor", Database: "test"})); !errors.Is(err, ErrRoleNotFound) {
+			t.Fatalf("AddRole() expected ErrRoleNotFound, got %v", err)
+		}
+	})
+	t.Run("built-in name", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		if err := AddRole(config, testRole("readWrite")); err == nil {
+			t.Fatal("AddRole() expected an error but got none")
+		}
+	})
+	t.Run("invalid resource", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		r := testRole("test")
+		cluster := true
+		r.Privileges[0].Resource.Cluster = &cluster
+		if err := AddRole(config, r); err == nil {
+			t.Fatal("AddRole() expected an error but got none")
+		}
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	t.Run("update", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		_ = AddRole(config, testRole("test"))
+		r := testRole("test")
+		r.Privileges[0].Actions = []string{"find"}
+		if err := UpdateRole(config, r); err != nil {
+			t.Fatalf("UpdateRole() unexpected error: %v", err)
+		}
+		if len(config.Roles) != 1 || len(config.Roles[0].Privileges[0].Actions) != 1 {
+			t.Errorf("role not updated: %+v", config.Roles)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		if err := UpdateRole(config, testRole("test")); !errors.Is(err, ErrRoleNotFound) {
+			t.Fatalf("UpdateRole() expected ErrRoleNotFound, got %v", err)
+		}
+	})
+	t.Run("cycle", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		_ = AddRole(config, testRole("a"))
+		_ = AddRole(config, testRole("b", &opsmngr.Role{Role: "a", Database: "test"}))
+		if err := UpdateRole(config, testRole("a", &opsmngr.Role{Role: "b", Database: "test"})); err == nil {
+			t.Fatal("UpdateRole() expected an error but got none")
+		}
+		if len(config.Roles[0].Roles) != 0 {
+			t.Error("config changed on error")
+		}
+	})
+}
+
+func TestRemoveRole(t *testing.T) {
+	t.Run("granted to a user", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		_ = AddRole(config, testRole("test"))
+		if err := RemoveRole(config, "test", "test"); !errors.Is(err, ErrRoleInUse) {
+			t.Fatalf("RemoveRole() expected ErrRoleInUse, got %v", err)
+		}
+	})
+	t.Run("inherited", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		_ = AddRole(config, testRole("a"))
+		_ = AddRole(config, testRole("b", &opsmngr.Role{Role: "a", Database: "test"}))
+		if err := RemoveRole(config, "a", "test"); !errors.Is(err, ErrRoleInUse) {
+			t.Fatalf("RemoveRole() expected ErrRoleInUse, got %v", err)
+		}
+		if err := RemoveRole(config, "b", "test"); err != nil {
+			t.Fatalf("RemoveRole() unexpected error: %v", err)
+		}
+		if err := RemoveRole(config, "a", "test"); err != nil {
+			t.Fatalf("RemoveRole() unexpected error: %v", err)
+		}
+		if len(config.Roles) != 0 {
+			t.Errorf("expected no roles, got %d", len(config.Roles))
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		config := automationConfigWithoutMongoDBUsers()
+		if err := RemoveRole(config, "test", "test"); !errors.Is(err, ErrRoleNotFound) {
+			t.Fatalf("RemoveRole() expected ErrRoleNotFound, got %v", err)
+		}
+	})
+}
@@ -16,7 +16,9 @@ package atmcfg
 
 import (
 	"crypto/hmac"
-	"crypto/md5" // #nosec G101 // #nosec G501 // used as part of the sha1 standard
+	"crypto/md5"  // #nosec G101 // #nosec G501 // used as part of the sha1 standard
+	"crypto/sha1" // #nosec G101 // #nosec G505 // mongodb scram-sha-1 supports this tho is not recommended
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -37,6 +39,53 @@ const (
 
 type hashingFunc func() hash.Hash
 
+// ScramVerification is the result of checking the SCRAM credentials of a user for one mechanism.
+type ScramVerification struct {
+	Mechanism          string // Mechanism is SCRAM-SHA-1 or SCRAM-SHA-256
+	Match              bool   // Match reports whether the credentials were computed from the password
+	IterationCount     int
+	WeakIterationCount bool // WeakIterationCount reports whether IterationCount is below the MongoDB default for the mechanism
+}
+
+// VerifyScramCredentials recomputes the SCRAM keys of the user from password, using the stored salt
+// and iteration count, and reports for each mechanism the user has credentials for whether they match.
+func VerifyScramCredentials(user *opsmngr.MongoDBUser, password string) ([]*ScramVerification, error) {
+	var results []*ScramVerification
+	if user.ScramSha1Creds != nil {
+		// SCRAM-SHA-1 keys are computed from the MONGODB-CR hash of the password
+		hashedPassword, err := md5Hex(user.Username + ":mongo:" + password)
+		if err != nil {
+			return nil, err
+		}
+		r, err := verifyScramCredentials(scramSha1, sha1.New, user.ScramSha1Creds, hashedPassword, scramSha1Iterations)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	if user.ScramSha256Creds != nil {
+		r, err := verifyScramCredentials(scramSha256, sha256.New, user.ScramSha256Creds, password, scramSha256Iterations)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func verifyScramCredentials(mechanism string, f hashingFunc, creds *opsmngr.ScramShaCreds, password string, minIterations int) (*ScramVerification, error) {
+	storedKey, serverKey, err := generateB64EncodedSecrets(f, password, creds.Salt, creds.IterationCount)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying %s credentials: %w", mechanism, err)
+	}
+	return &ScramVerification{
+		Mechanism:          mechanism,
+		Match:              hmac.Equal([]byte(storedKey), []byte(creds.StoredKey)) && hmac.Equal([]byte(serverKey), []byte(creds.ServerKey)),
+		IterationCount:     creds.IterationCount,
+		WeakIterationCount: creds.IterationCount < minIterations,
+	}, nil
+}
+
 func computeScramCredentials(f hashingFunc, iterationCount int, base64EncodedSalt, password string) (*opsmngr.ScramShaCreds, error) {
 	// password should be encrypted in the case of SCRAM-SHA-1 and unencrypted in the case of SCRAM-SHA-256
 	storedKey, serverKey, err := generateB64EncodedSecrets(f, password, base64EncodedSalt, iterationCount)
@@ -127,6 +176,12 @@ func hmacIteration(f hashingFunc, input, salt []byte, iterationCount int) ([]byt
 		return nil, fmt.Errorf("salt should have a size of %v bytes, but instead has a size of %v bytes", size, len(salt))
 	}
 
+	return pbkdf2Block(f, input, salt, iterationCount)
+}
+
+// pbkdf2Block computes the first block of PBKDF2 (RFC 2898) with HMAC f.
+func pbkdf2Block(f hashingFunc, input, salt []byte, iterationCount int) ([]byte, error) {
+	hashSize := f().Size()
 	startKey := append(salt, 0, 0, 0, 1) //nolint:gocritic // startKey is a copy of salt plus extra values
 	hmacHash := hmac.New(f, input)
 	if _, err := hmacHash.Write(startKey); err != nil {
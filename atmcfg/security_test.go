@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/ops-manager/opsmngr"
 )
 
 func TestGenerateB64EncodedSecrets(t *testing.T) {
@@ -60,3 +61,43 @@ func TestGenerateB64EncodedSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyScramCredentials(t *testing.T) {
+	u := &opsmngr.MongoDBUser{Username: "test", Database: "admin"}
+	if err := ConfigureScramCredentials(u, "password"); err != nil {
+		t.Fatalf("ConfigureScramCredentials() unexpected error: %v", err)
+	}
+
+	t.Run("match", func(t *testing.T) {
+		results, err := VerifyScramCredentials(u, "password")
+		a := assert.New(t)
+		if a.NoError(err) && a.Len(results, 2) {
+			a.Equal(&ScramVerification{Mechanism: scramSha1, Match: true, IterationCount: scramSha1Iterations}, results[0])
+			a.Equal(&ScramVerification{Mechanism: scramSha256, Match: true, IterationCount: scramSha256Iterations}, results[1])
+		}
+	})
+	t.Run("wrong password", func(t *testing.T) {
+		results, err := VerifyScramCredentials(u, "other")
+		a := assert.New(t)
+		if a.NoError(err) && a.Len(results, 2) {
+			a.False(results[0].Match)
+			a.False(results[1].Match)
+		}
+	})
+	t.Run("weak iteration count", func(t *testing.T) {
+		hashedPassword, err := md5Hex("test:mongo:password")
+		if err != nil {
+			t.Fatalf("md5Hex() unexpected error: %v", err)
+		}
+		creds, err := computeScramCredentials(sha1.New, 10, u.ScramSha1Creds.Salt, hashedPassword)
+		if err != nil {
+			t.Fatalf("computeScramCredentials() unexpected error: %v", err)
+		}
+		weak := &opsmngr.MongoDBUser{Username: "test", Database: "admin", ScramSha1Creds: creds}
+		results, err := VerifyScramCredentials(weak, "password")
+		a := assert.New(t)
+		if a.NoError(err) && a.Len(results, 1) {
+			a.Equal(&ScramVerification{Mechanism: scramSha1, Match: true, IterationCount: 10, WeakIterationCount: true}, results[0])
+		}
+	})
+}
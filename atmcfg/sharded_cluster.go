@@ -0,0 +1,271 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.mongodb.org/ops-manager/search"
+)
+
+const (
+	mongosProcessType = "mongos"
+	configServerRole  = "configsvr"
+	shardServerRole   = "shardsvr"
+)
+
+var (
+	// ErrShardNotFound means the sharded cluster has no shard with the given name.
+	ErrShardNotFound = errors.New("shard not found")
+	// ErrClusterNotFound means the config has no sharded cluster with the given name.
+	ErrClusterNotFound = errors.New("sharded cluster not found")
+)
+
+// ShardedClusterBuilder builds the processes, replica sets and sharding entries of a new sharded cluster.
+type ShardedClusterBuilder struct {
+	name         string
+	version      string
+	fcv          string
+	dataDir      string
+	configServer *ReplicaSetBuilder
+	shards       []*ReplicaSetBuilder
+	mongos       []memberSpec
+}
+
+// NewShardedCluster returns a builder for a sharded cluster with the given name.
+func NewShardedCluster(name string) *ShardedClusterBuilder {
+	return &ShardedClusterBuilder{name: name, dataDir: defaultDataDir}
+}
+
+// WithVersion sets the MongoDB version of every process, unless set on the replica set.
+func (b *ShardedClusterBuilder) WithVersion(version string) *ShardedClusterBuilder {
+	b.version = version
+	return b
+}
+
+// WithFeatureCompatibilityVersion sets the feature compatibility version of every process, unless set on the replica set.
+func (b *ShardedClusterBuilder) WithFeatureCompatibilityVersion(fcv string) *ShardedClusterBuilder {
+	b.fcv = fcv
+	return b
+}
+
+// WithDataDir sets the directory containing the data directory of each process, defaults to /data.
+func (b *ShardedClusterBuilder) WithDataDir(dir string) *ShardedClusterBuilder {
+	b.dataDir = dir
+	return b
+}
+
+// WithConfigServer sets the config server replica set.
+func (b *ShardedClusterBuilder) WithConfigServer(rs *ReplicaSetBuilder) *ShardedClusterBuilder {
+	b.configServer = rs
+	return b
+}
+
+// WithShard adds a shard, the shard _id is the replica set name.
+func (b *ShardedClusterBuilder) WithShard(rs *ReplicaSetBuilder) *ShardedClusterBuilder {
+	b.shards = append(b.shards, rs)
+	return b
+}
+
+// WithMongos adds a mongos running on host and port.
+func (b *ShardedClusterBuilder) WithMongos(host string, port int) *ShardedClusterBuilder {
+	b.mongos = append(b.mongos, memberSpec{host: host, port: port})
+	return b
+}
+
+// Build returns the processes, the replica sets and the sharding config of the cluster.
+func (b *ShardedClusterBuilder) Build() ([]*opsmngr.Process, []*opsmngr.ReplicaSet, *opsmngr.ShardingConfig, error) {
+	if b.name == "" {
+		return nil, nil, nil, errors.New("sharded cluster name must be set")
+	}
+	if b.configServer == nil {
+		return nil, nil, nil, fmt.Errorf("sharded cluster %s: config server must be set", b.name)
+	}
+	if len(b.shards) == 0 {
+		return nil, nil, nil, fmt.Errorf("sharded cluster %s: at least one shard must be added", b.name)
+	}
+	if len(b.mongos) == 0 {
+		return nil, nil, nil, fmt.Errorf("sharded cluster %s: at least one mongos must be added", b.name)
+	}
+
+	var processes []*opsmngr.Process
+	var replicaSets []*opsmngr.ReplicaSet
+	sharding := &opsmngr.ShardingConfig{
+		Name:                b.name,
+		ConfigServerReplica: b.configServer.name,
+		Shards:              make([]*opsmngr.Shard, 0, len(b.shards)),
+		Draining:            make([]string, 0),
+	}
+
+	configProcesses, configRS, err := b.replicaSet(b.configServer, configServerRole).Build()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	processes = append(processes, configProcesses...)
+	replicaSets = append(replicaSets, configRS)
+
+	for _, shard := range b.shards {
+		shardProcesses, shardRS, err := b.replicaSet(shard, shardServerRole).Build()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		processes = append(processes, shardProcesses...)
+		replicaSets = append(replicaSets, shardRS)
+		sharding.Shards = append(sharding.Shards, &opsmngr.Shard{ID: shardRS.ID, RS: shardRS.ID})
+	}
+
+	for i, m := range b.mongos {
+		if m.host == "" {
+			return nil, nil, nil, fmt.Errorf("sharded cluster %s: mongos %d: host must be set", b.name, i)
+		}
+		processes = append(processes, newMongos(b.name, fmt.Sprintf("%s_mongos_%d", b.name, i), m, b.version, b.fcv, b.dataDir))
+	}
+
+	return processes, replicaSets, sharding, nil
+}
+
+// AddTo builds the sharded cluster and appends it to out.
+func (b *ShardedClusterBuilder) AddTo(out *opsmngr.AutomationConfig) error {
+	processes, replicaSets, sharding, err := b.Build()
+	if err != nil {
+		return err
+	}
+	if _, found := search.ShardingConfig(out.Sharding, func(s *opsmngr.ShardingConfig) bool {
+		return s.Name == sharding.Name
+	}); found {
+		return fmt.Errorf("sharded cluster %s %w", sharding.Name, ErrAlreadyExists)
+	}
+	for _, rs := range replicaSets {
+		if err := checkNotExists(out, nil, rs); err != nil {
+			return err
+		}
+	}
+	if err := checkNotExists(out, processes, nil); err != nil {
+		return err
+	}
+
+	out.Processes = append(out.Processes, processes...)
+	out.ReplicaSets = append(out.ReplicaSets, replicaSets...)
+	out.Sharding = append(out.Sharding, sharding)
+	return nil
+}
+
+// replicaSet returns a copy of rs with the cluster defaults.
+func (b *ShardedClusterBuilder) replicaSet(rs *ReplicaSetBuilder, role string) *ReplicaSetBuilder {
+	c := *rs
+	c.clusterRole = role
+	if c.version == "" {
+		c.version = b.version
+	}
+	if c.fcv == "" {
+		c.fcv = b.fcv
+	}
+	if c.dataDir == defaultDataDir {
+		c.dataDir = b.dataDir
+	}
+	return &c
+}
+
+func newMongos(clusterName, name string, m memberSpec, version, fcv, dataDir string) *opsmngr.Process {
+	p := newProcess(name, m.host, m.port, version, fcv, mongosProcessType)
+	p.Cluster = clusterName
+	p.Args26.SystemLog.Path = path.Join(dataDir, name, "mongos.log")
+	return p
+}
+
+// AddShard adds a new shard replica set to the sharded cluster clusterName,
+// the version of the shard defaults to the version of the cluster.
+func AddShard(out *opsmngr.AutomationConfig, clusterName string, rs *ReplicaSetBuilder) error {
+	s, err := shardingConfig(out, clusterName)
+	if err != nil {
+		return err
+	}
+
+	b := &ShardedClusterBuilder{name: clusterName, dataDir: rs.dataDir}
+	if i, found := search.Processes(out.Processes, func(p *opsmngr.Process) bool {
+		return p.Cluster == clusterName
+	}); found {
+		b.version = out.Processes[i].Version
+		b.fcv = out.Processes[i].FeatureCompatibilityVersion
+	}
+	processes, shardRS, err := b.replicaSet(rs, shardServerRole).Build()
+	if err != nil {
+		return err
+	}
+	if err := checkNotExists(out, processes, shardRS); err != nil {
+		return err
+	}
+
+	out.Processes = append(out.Processes, processes...)
+	out.ReplicaSets = append(out.ReplicaSets, shardRS)
+	s.Shards = append(s.Shards, &opsmngr.Shard{ID: shardRS.ID, RS: shardRS.ID})
+	return nil
+}
+
+// DrainShard removes the shard shardName from the sharded cluster clusterName.
+//
+// Data must be migrated off a shard before removing it, so the first call only adds the shard
+// to the draining list of the cluster, and the config must be pushed for the agents to start draining.
+// Following calls remove the shard, its replica set and processes once status shows the agents reached
+// the goal state of a config version where the shard was already draining.
+// DrainShard returns whether the shard was removed, see the steps in the package documentation.
+func DrainShard(out *opsmngr.AutomationConfig, clusterName, shardName string, status *opsmngr.AutomationStatus) (bool, error) {
+	s, err := shardingConfig(out, clusterName)
+	if err != nil {
+		return false, err
+	}
+	i, found := search.Shards(s.Shards, func(shard *opsmngr.Shard) bool {
+		return shard.ID == shardName
+	})
+	if !found {
+		return false, fmt.Errorf("%w: %s in %s", ErrShardNotFound, shardName, clusterName)
+	}
+
+	if !stringInSlice(s.Draining, shardName) {
+		if len(s.Shards) == 1 {
+			return false, fmt.Errorf("can't drain %s, the last shard of %s", shardName, clusterName)
+		}
+		s.Draining = append(s.Draining, shardName)
+		return false, nil
+	}
+	if !reachedGoal(out, status) {
+		return false, nil
+	}
+
+	rsName := s.Shards[i].RS
+	s.Shards = append(s.Shards[:i], s.Shards[i+1:]...)
+	draining := make([]string, 0, len(s.Draining))
+	for _, d := range s.Draining {
+		if d != shardName {
+			draining = append(draining, d)
+		}
+	}
+	s.Draining = draining
+	removeByReplicaSetName(out, rsName)
+	return true, nil
+}
+
+func shardingConfig(out *opsmngr.AutomationConfig, clusterName string) (*opsmngr.ShardingConfig, error) {
+	i, found := search.ShardingConfig(out.Sharding, func(s *opsmngr.ShardingConfig) bool {
+		return s.Name == clusterName
+	})
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, clusterName)
+	}
+	return out.Sharding[i], nil
+}
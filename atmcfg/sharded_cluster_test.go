@@ -0,0 +1,128 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func newTestShardedCluster(t *testing.T) *opsmngr.AutomationConfig {
+	t.Helper()
+	out := &opsmngr.AutomationConfig{Auth: opsmngr.Auth{Disabled: true}}
+	err := NewShardedCluster(clusterName).
+		WithVersion("6.0.5").
+		WithConfigServer(NewReplicaSet(clusterName+"_configRS").WithMember("host0", 27019, nil)).
+		WithShard(NewReplicaSet(clusterName+"_shard_0").WithMember("host0", 27018, nil)).
+		WithShard(NewReplicaSet(clusterName+"_shard_1").WithMember("host1", 27018, nil)).
+		WithMongos("host0", 27017).
+		AddTo(out)
+	if err != nil {
+		t.Fatalf("AddTo() returned an error: %v", err)
+	}
+	return out
+}
+
+func TestShardedClusterBuilder(t *testing.T) {
+	out := newTestShardedCluster(t)
+	if err := Validate(out); err != nil {
+		t.Errorf("Validate() returned an error: %v", err)
+	}
+
+	if len(out.Processes) != 4 || len(out.ReplicaSets) != 3 || len(out.Sharding) != 1 {
+		t.Fatalf("got %d processes, %d replica sets and %d clusters", len(out.Processes), len(out.ReplicaSets), len(out.Sharding))
+	}
+	roles := map[string]string{}
+	for _, p := range out.Processes {
+		if p.Version != "6.0.5" {
+			t.Errorf("%s: got version %s", p.Name, p.Version)
+		}
+		if p.Args26.Sharding != nil {
+			roles[p.Name] = p.Args26.Sharding.ClusterRole
+		}
+	}
+	if roles[clusterName+"_configRS_0"] != configServerRole || roles[clusterName+"_shard_1_0"] != shardServerRole {
+		t.Errorf("unexpected cluster roles %v", roles)
+	}
+	if mongos := out.Processes[3]; mongos.ProcessType != mongosProcessType || mongos.Cluster != clusterName {
+		t.Errorf("unexpected mongos %+v", mongos)
+	}
+
+	if err := NewShardedCluster(clusterName).WithVersion("6.0.5").AddTo(out); err == nil {
+		t.Error("expected an error without config server")
+	}
+}
+
+func TestAddShard(t *testing.T) {
+	out := newTestShardedCluster(t)
+	if err := AddShard(out, clusterName, NewReplicaSet(clusterName+"_shard_2").WithMember("host2", 27018, nil)); err != nil {
+		t.Fatalf("AddShard() returned an error: %v", err)
+	}
+	if err := Validate(out); err != nil {
+		t.Errorf("Validate() returned an error: %v", err)
+	}
+	shards := out.Sharding[0].Shards
+	if len(shards) != 3 || shards[2].ID != clusterName+"_shard_2" {
+		t.Errorf("expected the shard to be registered, got %v", shards)
+	}
+	if p := out.Processes[len(out.Processes)-1]; p.Version != "6.0.5" || p.Args26.Sharding.ClusterRole != shardServerRole {
+		t.Errorf("expected the shard to use the cluster version, got %+v", p)
+	}
+
+	if err := AddShard(out, "unknown", NewReplicaSet("rs").WithMember("host2", 0, nil)); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("expected ErrClusterNotFound, got %v", err)
+	}
+}
+
+func TestDrainShard(t *testing.T) {
+	out := newTestShardedCluster(t)
+	out.Version = 1
+	shard := clusterName + "_shard_1"
+
+	removed, err := DrainShard(out, clusterName, shard, nil)
+	if err != nil || removed {
+		t.Fatalf("DrainShard() = %v, %v", removed, err)
+	}
+	if d := out.Sharding[0].Draining; len(d) != 1 || d[0] != shard {
+		t.Fatalf("expected the shard to be draining, got %v", d)
+	}
+
+	// the config with the draining shard is pushed as version 2
+	out.Version = 2
+	removed, _ = DrainShard(out, clusterName, shard, statusAt(2, 1, 2))
+	if removed {
+		t.Error("the shard must not be removed before reaching the goal state")
+	}
+
+	removed, err = DrainShard(out, clusterName, shard, statusAt(2, 2, 2))
+	if err != nil || !removed {
+		t.Fatalf("DrainShard() = %v, %v", removed, err)
+	}
+	if err := Validate(out); err != nil {
+		t.Errorf("Validate() returned an error: %v", err)
+	}
+	if s := out.Sharding[0]; len(s.Shards) != 1 || len(s.Draining) != 0 || len(out.ReplicaSets) != 2 || len(out.Processes) != 3 {
+		t.Errorf("expected the shard to be removed, got %+v", s)
+	}
+
+	if _, err := DrainShard(out, clusterName, shard, nil); !errors.Is(err, ErrShardNotFound) {
+		t.Errorf("expected ErrShardNotFound, got %v", err)
+	}
+	if _, err := DrainShard(out, clusterName, clusterName+"_shard_0", nil); err == nil {
+		t.Error("expected an error draining the last shard")
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+// TLS modes of a process, in the order a cluster must go through them.
+const (
+	TLSModeDisabled = "disabled"
+	TLSModeAllow    = "allowTLS"
+	TLSModePrefer   = "preferTLS"
+	TLSModeRequire  = "requireTLS"
+)
+
+// tlsMinVersion is the first MongoDB version with the tls options, older versions use ssl.
+const tlsMinVersion = "4.2.0"
+
+var tlsModes = []string{TLSModeDisabled, TLSModeAllow, TLSModePrefer, TLSModeRequire}
+
+// ErrMissingCertificate means TLS can't be enabled on a process without a certificate key file.
+var ErrMissingCertificate = errors.New("certificate key file not set")
+
+// TLSCertificates are the certificate paths of a process.
+type TLSCertificates struct {
+	CAFile                     string
+	CertificateKeyFile         string
+	CertificateKeyFilePassword string
+	ClusterFile                string // ClusterFile is the certificate for membership authentication, CertificateKeyFile is used if not set
+}
+
+// SetTLSCertificates sets the certificate paths of every process of the cluster clusterName.
+func SetTLSCertificates(out *opsmngr.AutomationConfig, clusterName string, certs *TLSCertificates) error {
+	return RotateTLSCertificates(out, clusterName, nil, certs)
+}
+
+// RotateTLSCertificates sets the certificate paths of the given processes of the cluster clusterName,
+// processes are in the form hostname:port, all processes are updated if none are given.
+// The automation agents restart the processes to use the new certificates.
+func RotateTLSCertificates(out *opsmngr.AutomationConfig, clusterName string, processes []string, certs *TLSCertificates) error {
+	if certs == nil || certs.CertificateKeyFile == "" {
+		return ErrMissingCertificate
+	}
+	clusterProcs := clusterProcesses(out, clusterName)
+	if len(clusterProcs) == 0 {
+		return fmt.Errorf("%w: %s", ErrProcessNotFound, clusterName)
+	}
+	processesMap := newProcessMap(processes)
+	for _, p := range clusterProcs {
+		key := fmt.Sprintf("%s:%d", p.Hostname, p.Args26.NET.Port)
+		if len(processesMap) > 0 {
+			if _, ok := processesMap[key]; !ok {
+				continue
+			}
+			processesMap[key] = true
+		}
+		tls := processTLS(p)
+		tls.CAFile = certs.CAFile
+		tls.CertificateKeyFile = certs.CertificateKeyFile
+		tls.CertificateKeyFilePassword = certs.CertificateKeyFilePassword
+		tls.ClusterFile = certs.ClusterFile
+		setProcessTLS(p, tls)
+	}
+	return newProcessNotFoundError(clusterName, processesMap)
+}
+
+// SetAgentTLS sets the TLS settings the agents use to connect to the processes,
+// clientCertificateMode is OPTIONAL or REQUIRE depending on whether processes require client certificates.
+// The other agent TLS settings, like the PEM key file of the agents, are kept.
+func SetAgentTLS(out *opsmngr.AutomationConfig, caFilePath, clientCertificateMode string) error {
+	if caFilePath == "" {
+		return errors.New("CA file path must be set")
+	}
+	if out.TLS == nil {
+		out.TLS = new(opsmngr.SSL)
+	}
+	out.TLS.CAFilePath = caFilePath
+	out.TLS.ClientCertificateMode = clientCertificateMode
+	return nil
+}
+
+// SetTLSMode moves the processes of the cluster clusterName one step towards the TLS mode target
+// and reports whether every process is in that mode.
+//
+// Processes must go through disabled, allowTLS, preferTLS and requireTLS one mode at a time,
+// waiting for every process to reach the goal state before the next mode, otherwise members
+// using different modes can't talk to each other, so SetTLSMode moves one step at a time,
+// see the steps in the package documentation.
+func SetTLSMode(out *opsmngr.AutomationConfig, clusterName, target string, status *opsmngr.AutomationStatus) (bool, error) {
+	targetIndex := tlsModeIndex(target)
+	if targetIndex < 0 {
+		return false, fmt.Errorf("invalid TLS mode %s, expected one of %s", target, strings.Join(tlsModes, ", "))
+	}
+	processes := clusterProcesses(out, clusterName)
+	if len(processes) == 0 {
+		return false, fmt.Errorf("%w: %s", ErrProcessNotFound, clusterName)
+	}
+
+	// the mode furthest from the target is the one every process must leave
+	current := targetIndex
+	for _, p := range processes {
+		i := tlsModeIndex(processTLSMode(p))
+		if abs(i-targetIndex) > abs(current-targetIndex) {
+			current = i
+		}
+		if targetIndex > 0 && processTLS(p).CertificateKeyFile == "" {
+			return false, fmt.Errorf("%w: %s", ErrMissingCertificate, p.Name)
+		}
+	}
+	if current == targetIndex {
+		return true, nil
+	}
+	if !reachedGoal(out, status) {
+		return false, nil
+	}
+
+	next := current + 1
+	if current > targetIndex {
+		next = current - 1
+	}
+	for _, p := range processes {
+		tls := processTLS(p)
+		tls.Mode = tlsModes[next]
+		setProcessTLS(p, tls)
+	}
+	return false, nil
+}
+
+func tlsModeIndex(mode string) int {
+	for i, m := range tlsModes {
+		if m == mode {
+			return i
+		}
+	}
+	return -1
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// processTLSMode returns the TLS mode of the process, using the TLS names for ssl modes.
+func processTLSMode(p *opsmngr.Process) string {
+	mode := processTLS(p).Mode
+	if mode == "" {
+		return TLSModeDisabled
+	}
+	return strings.Replace(mode, "SSL", "TLS", 1)
+}
+
+// processTLS returns a copy of the TLS options of p, read from the deprecated ssl options if needed.
+func processTLS(p *opsmngr.Process) opsmngr.TLS {
+	switch {
+	case p.Args26.NET.TLS != nil:
+		return *p.Args26.NET.TLS
+	case p.Args26.NET.SSL != nil:
+		tls := *p.Args26.NET.SSL
+		tls.Mode = strings.Replace(tls.Mode, "SSL", "TLS", 1)
+		if tls.CertificateKeyFile == "" {
+			tls.CertificateKeyFile, tls.PEMKeyFile = tls.PEMKeyFile, ""
+		}
+		return tls
+	}
+	return opsmngr.TLS{}
+}
+
+// setProcessTLS sets the TLS options of p, using the ssl options before MongoDB 4.2.
+func setProcessTLS(p *opsmngr.Process, tls opsmngr.TLS) {
+	if compareVersions(p.Version, tlsMinVersion) >= 0 {
+		p.Args26.NET.SSL = nil
+		p.Args26.NET.TLS = &tls
+		return
+	}
+	if tls.Mode != TLSModeDisabled {
+		tls.Mode = strings.Replace(tls.Mode, "TLS", "SSL", 1)
+	}
+	tls.PEMKeyFile, tls.CertificateKeyFile = tls.CertificateKeyFile, ""
+	p.Args26.NET.TLS = nil
+	p.Args26.NET.SSL = &tls
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func TestSetTLSMode(t *testing.T) {
+	out := automationConfigWithOneShardedCluster(clusterName, false)
+	if _, err := SetTLSMode(out, clusterName, TLSModeRequire, nil); !errors.Is(err, ErrMissingCertificate) {
+		t.Fatalf("expected ErrMissingCertificate, got %v", err)
+	}
+	if err := SetTLSCertificates(out, clusterName, &TLSCertificates{CAFile: "/etc/ssl/ca.pem", CertificateKeyFile: "/etc/ssl/server.pem"}); err != nil {
+		t.Fatalf("SetTLSCertificates() returned an error: %v", err)
+	}
+
+	var modes []string
+	for i := 0; i < 10; i++ {
+		done, err := SetTLSMode(out, clusterName, TLSModeRequire, statusAt(out.Version, out.Version))
+		if err != nil {
+			t.Fatalf("SetTLSMode() returned an error: %v", err)
+		}
+		if done {
+			break
+		}
+		for _, p := range out.Processes {
+			if processTLSMode(p) != processTLSMode(out.Processes[0]) {
+				t.Fatalf("expected every process in the same mode")
+			}
+		}
+		modes = append(modes, processTLSMode(out.Processes[0]))
+
+		// nothing changes until the goal state is reached
+		out.Version++
+		if _, err := SetTLSMode(out, clusterName, TLSModeRequire, statusAt(out.Version, out.Version-1)); err != nil || processTLSMode(out.Processes[0]) != modes[len(modes)-1] {
+			t.Fatalf("expected the mode to be kept until goal state")
+		}
+	}
+	if diff := deep.Equal(modes, []string{TLSModeAllow, TLSModePrefer, TLSModeRequire}); diff != nil {
+		t.Error(diff)
+	}
+	// 4.2 processes use the tls options
+	if tls := out.Processes[0].Args26.NET.TLS; tls == nil || tls.Mode != TLSModeRequire || tls.CertificateKeyFile != "/etc/ssl/server.pem" {
+		t.Errorf("unexpected TLS options %+v", tls)
+	}
+
+	// going back is one step at a time too
+	if _, err := SetTLSMode(out, clusterName, TLSModeDisabled, statusAt(out.Version, out.Version)); err != nil || processTLSMode(out.Processes[0]) != TLSModePrefer {
+		t.Errorf("expected preferTLS, got %s, %v", processTLSMode(out.Processes[0]), err)
+	}
+}
+
+func TestRotateTLSCertificates(t *testing.T) {
+	out := automationConfigWithOneReplicaSet(clusterName, false)
+	out.Processes[0].Version = "4.0.0"
+	if err := SetTLSCertificates(out, clusterName, &TLSCertificates{CertificateKeyFile: "/etc/ssl/old.pem"}); err != nil {
+		t.Fatalf("SetTLSCertificates() returned an error: %v", err)
+	}
+	if err := RotateTLSCertificates(out, clusterName, []string{"host0:27017"}, &TLSCertificates{CertificateKeyFile: "/etc/ssl/new.pem"}); err != nil {
+		t.Fatalf("RotateTLSCertificates() returned an error: %v", err)
+	}
+	// 4.0 processes use the ssl options
+	if ssl := out.Processes[0].Args26.NET.SSL; ssl == nil || ssl.PEMKeyFile != "/etc/ssl/new.pem" || out.Processes[0].Args26.NET.TLS != nil {
+		t.Errorf("unexpected SSL options %+v", ssl)
+	}
+
+	err := RotateTLSCertificates(out, clusterName, []string{"host1:27017"}, &TLSCertificates{CertificateKeyFile: "/etc/ssl/new.pem"})
+	if !errors.Is(err, ErrProcessNotFound) {
+		t.Errorf("expected ErrProcessNotFound, got %v", err)
+	}
+}
+
+func TestSetAgentTLS(t *testing.T) {
+	out := automationConfigWithOneReplicaSet(clusterName, false)
+	if err := SetAgentTLS(out, "/etc/ssl/ca.pem", "OPTIONAL"); err != nil {
+		t.Fatalf("SetAgentTLS() returned an error: %v", err)
+	}
+	if out.TLS.CAFilePath != "/etc/ssl/ca.pem" || out.TLS.ClientCertificateMode != "OPTIONAL" {
+		t.Errorf("unexpected agent TLS settings %+v", out.TLS)
+	}
+	if err := SetAgentTLS(out, "", ""); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestSetAgentTLS_keepsOtherSettings(t *testing.T) {
+	out := automationConfigWithOneReplicaSet(clusterName, false)
+	out.TLS = &opsmngr.SSL{
+		AutoPEMKeyFilePath: "/etc/ssl/agent.pem",
+		AutoPEMKeyFilePwd:  "secret",
+		CAFilePath:         "/etc/ssl/old-ca.pem",
+	}
+	if err := SetAgentTLS(out, "/etc/ssl/ca.pem", "REQUIRE"); err != nil {
+		t.Fatalf("SetAgentTLS() returned an error: %v", err)
+	}
+	expected := &opsmngr.SSL{
+		AutoPEMKeyFilePath:    "/etc/ssl/agent.pem",
+		AutoPEMKeyFilePwd:     "secret",
+		CAFilePath:            "/etc/ssl/ca.pem",
+		ClientCertificateMode: "REQUIRE",
+	}
+	if diff := deep.Equal(out.TLS, expected); diff != nil {
+		t.Error(diff)
+	}
+}
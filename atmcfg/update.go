@@ -0,0 +1,242 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+const maxUpdateAttempts = 5
+
+// ErrVersionConflict means the automation config kept being modified concurrently
+// and Update gave up after maxUpdateAttempts attempts.
+var ErrVersionConflict = errors.New("automation config version conflict")
+
+// ConfigService is the part of opsmngr.AutomationService used by Update.
+type ConfigService interface {
+	GetConfig(context.Context, string) (*opsmngr.AutomationConfig, *opsmngr.Response, error)
+	UpdateConfig(context.Context, string, *opsmngr.AutomationConfig) (*opsmngr.Response, error)
+}
+
+var _ ConfigService = &opsmngr.AutomationServiceOp{}
+
+// Mutation modifies an automation config, for example with Shutdown or EnableMechanism.
+// Update may call it more than once, with a fresh config each time.
+type Mutation func(out *opsmngr.AutomationConfig) error
+
+// Conflict is a field changed both by a mutation and concurrently by someone else.
+type Conflict struct {
+	Kind   string
+	ID     string
+	Path   string      // Path of the field, empty when the whole element is in conflict
+	Ours   interface{} // Ours is the value set by the mutation
+	Theirs interface{} // Theirs is the value set concurrently
+}
+
+// ConflictError is returned by Update when the mutation and a concurrent change collide.
+type ConflictError struct {
+	Conflicts []*Conflict
+}
+
+func (e *ConflictError) Error() string {
+	s := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		s[i] = (&Change{Kind: c.Kind, ID: c.ID}).title()
+		if c.Path != "" {
+			s[i] += " " + c.Path
+		}
+	}
+	return fmt.Sprintf("conflicting concurrent changes to the automation config: %s", strings.Join(s, ", "))
+}
+
+// Update gets the automation config of the project, applies mutate and updates the config.
+//
+// Ops Manager doesn't always reject an update based on an outdated config, so the config is read
+// again before every update. If it was modified since it was read, or if the update is rejected,
+// mutate is re-applied to the latest config, as long as the changes made by mutate and the
+// concurrent changes don't touch the same fields. Otherwise, a *ConflictError is returned.
+// Update returns the config it pushed, or the current config if mutate didn't change anything.
+func Update(ctx context.Context, s ConfigService, groupID string, mutate Mutation) (*opsmngr.AutomationConfig, error) {
+	base, _, err := s.GetConfig(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	ours, err := apply(base, mutate)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		if Diff(base, ours).IsEmpty() {
+			return base, nil
+		}
+
+		theirs, err := push(ctx, s, groupID, base, ours)
+		if err != nil {
+			return nil, err
+		}
+		if theirs == nil {
+			return ours, nil
+		}
+		if attempt == maxUpdateAttempts {
+			return nil, fmt.Errorf("%w: giving up after %d attempts", ErrVersionConflict, attempt)
+		}
+		if conflicts := Conflicts(base, ours, theirs); len(conflicts) > 0 {
+			return nil, &ConflictError{Conflicts: conflicts}
+		}
+
+		base = theirs
+		if ours, err = apply(theirs, mutate); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// push updates the config to ours if it's still at the version of base.
+// It returns the latest config when it was modified concurrently, and nil once ours is pushed.
+func push(ctx context.Context, s ConfigService, groupID string, base, ours *opsmngr.AutomationConfig) (*opsmngr.AutomationConfig, error) {
+	theirs, _, err := s.GetConfig(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if theirs.Version != base.Version {
+		return theirs, nil
+	}
+
+	resp, err := s.UpdateConfig(ctx, groupID, ours)
+	if err == nil {
+		return nil, nil
+	}
+	var errResp *opsmngr.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return nil, err
+	}
+
+	// the update was rejected, it's a conflict if the version changed since we read it
+	theirs, _, getErr := s.GetConfig(ctx, groupID)
+	if getErr != nil {
+		return nil, getErr
+	}
+	if theirs.Version == base.Version && (resp == nil || resp.StatusCode != http.StatusConflict) {
+		return nil, err
+	}
+	return theirs, nil
+}
+
+// Conflicts returns the fields of base changed differently in ours and in theirs.
+// Changes to different fields of the same element, or identical changes, are not conflicts.
+func Conflicts(base, ours, theirs *opsmngr.AutomationConfig) []*Conflict {
+	ourDiff, theirDiff := Diff(base, ours), Diff(base, theirs)
+	ourChanges, theirChanges := changesByKey(ourDiff), changesByKey(theirDiff)
+	// what differs between both sides
+	divergence := Diff(theirs, ours)
+
+	var conflicts []*Conflict
+	for _, d := range divergence {
+		k := changeKey(d)
+		our, theirsChanged := ourChanges[k], theirChanges[k]
+		if our == nil || theirsChanged == nil {
+			continue
+		}
+		if our.Type != ChangeUpdate || theirsChanged.Type != ChangeUpdate {
+			conflicts = append(conflicts, &Conflict{Kind: d.Kind, ID: d.ID})
+			continue
+		}
+		ourPaths, theirPaths := fieldPaths(our), fieldPaths(theirsChanged)
+		for _, f := range d.Fields {
+			if ourPaths[f.Path] && theirPaths[f.Path] {
+				conflicts = append(conflicts, &Conflict{Kind: d.Kind, ID: d.ID, Path: f.Path, Ours: f.New, Theirs: f.Old})
+			}
+		}
+	}
+
+	// deleting a replica set conflicts with changes to its members
+	for _, pair := range [][2]Changes{{ourDiff, theirDiff}, {theirDiff, ourDiff}} {
+		for _, c := range pair[0] {
+			if c.Kind != KindReplicaSet || c.Type != ChangeDelete {
+				continue
+			}
+			for _, m := range pair[1] {
+				if m.Kind == KindMember && strings.HasPrefix(m.ID, c.ID+"/") {
+					conflicts = append(conflicts, &Conflict{Kind: c.Kind, ID: c.ID})
+					break
+				}
+			}
+		}
+	}
+
+	return conflicts
+}
+
+func changeKey(c *Change) string {
+	return c.Kind + "\x00" + c.ID
+}
+
+func changesByKey(changes Changes) map[string]*Change {
+	m := make(map[string]*Change, len(changes))
+	for _, c := range changes {
+		m[changeKey(c)] = c
+	}
+	return m
+}
+
+// fieldPaths returns the paths changed and all their parents, so changing a whole object
+// on one side and one of its fields on the other is a conflict.
+func fieldPaths(c *Change) map[string]bool {
+	paths := make(map[string]bool, len(c.Fields))
+	for _, f := range c.Fields {
+		p := f.Path
+		for {
+			paths[p] = true
+			i := strings.LastIndex(p, ".")
+			if i < 0 {
+				break
+			}
+			p = p[:i]
+		}
+	}
+	return paths
+}
+
+// apply returns a copy of in modified by mutate.
+func apply(in *opsmngr.AutomationConfig, mutate Mutation) (*opsmngr.AutomationConfig, error) {
+	out, err := deepCopy(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := mutate(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func deepCopy(in *opsmngr.AutomationConfig) (*opsmngr.AutomationConfig, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := new(opsmngr.AutomationConfig)
+	if err := json.Unmarshal(b, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
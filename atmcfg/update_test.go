@@ -0,0 +1,186 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+const groupID = "5a0a1e7e0f2912c554080adc"
+
+// fakeConfigService is a ConfigService enforcing the config version unless blind is set,
+// concurrent runs before every update to simulate someone else changing the config.
+type fakeConfigService struct {
+	config     *opsmngr.AutomationConfig
+	concurrent []func(*opsmngr.AutomationConfig)
+	blind      bool
+	updates    int
+}
+
+func (s *fakeConfigService) GetConfig(context.Context, string) (*opsmngr.AutomationConfig, *opsmngr.Response, error) {
+	c, err := deepCopy(s.config)
+	return c, nil, err
+}
+
+func (s *fakeConfigService) UpdateConfig(_ context.Context, _ string, c *opsmngr.AutomationConfig) (*opsmngr.Response, error) {
+	if len(s.concurrent) > 0 {
+		s.concurrent[0](s.config)
+		s.concurrent = s.concurrent[1:]
+		s.config.Version++
+	}
+	if !s.blind && c.Version != s.config.Version {
+		resp := &http.Response{StatusCode: http.StatusConflict, Request: &http.Request{Method: http.MethodPut}}
+		return &opsmngr.Response{Response: resp}, &opsmngr.ErrorResponse{Response: resp, HTTPCode: http.StatusConflict}
+	}
+	s.updates++
+	s.config, _ = deepCopy(c)
+	s.config.Version++
+	return &opsmngr.Response{}, nil
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no conflict", func(t *testing.T) {
+		s := &fakeConfigService{config: automationConfigWithOneReplicaSet(clusterName, false)}
+		got, err := Update(ctx, s, groupID, func(out *opsmngr.AutomationConfig) error {
+			Shutdown(out, clusterName)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Update() returned an error: %v", err)
+		}
+		if !got.Processes[0].Disabled || !s.config.Processes[0].Disabled || s.updates != 1 {
+			t.Errorf("expected the process to be disabled with a single update")
+		}
+	})
+	t.Run("no changes", func(t *testing.T) {
+		s := &fakeConfigService{config: automationConfigWithOneReplicaSet(clusterName, false)}
+		if _, err := Update(ctx, s, groupID, func(*opsmngr.AutomationConfig) error { return nil }); err != nil {
+			t.Fatalf("Update() returned an error: %v", err)
+		}
+		if s.updates != 0 {
+			t.Errorf("expected no updates, got %d", s.updates)
+		}
+	})
+	t.Run("concurrent change merged", func(t *testing.T) {
+		s := &fakeConfigService{
+			config: automationConfigWithOneReplicaSet(clusterName, false),
+			concurrent: []func(*opsmngr.AutomationConfig){
+				func(c *opsmngr.AutomationConfig) { c.Processes[0].Version = "4.4.0" },
+			},
+		}
+		calls := 0
+		_, err := Update(ctx, s, groupID, func(out *opsmngr.AutomationConfig) error {
+			calls++
+			Shutdown(out, clusterName)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Update() returned an error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("expected the mutation to be re-applied, got %d calls", calls)
+		}
+		if p := s.config.Processes[0]; !p.Disabled || p.Version != "4.4.0" {
+			t.Errorf("expected both changes to be kept, got disabled=%v version=%s", p.Disabled, p.Version)
+		}
+	})
+	t.Run("concurrent change merged without version check", func(t *testing.T) {
+		s := &fakeConfigService{config: automationConfigWithOneReplicaSet(clusterName, false), blind: true}
+		calls := 0
+		_, err := Update(ctx, s, groupID, func(out *opsmngr.AutomationConfig) error {
+			calls++
+			if calls == 1 {
+				// someone else updates the config while the mutation runs
+				s.config.Processes[0].Version = "4.4.0"
+				s.config.Version++
+			}
+			Shutdown(out, clusterName)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Update() returned an error: %v", err)
+		}
+		if calls != 2 || s.updates != 1 {
+			t.Errorf("expected the mutation to be re-applied before a single update, got %d calls and %d updates", calls, s.updates)
+		}
+		if p := s.config.Processes[0]; !p.Disabled || p.Version != "4.4.0" {
+			t.Errorf("expected the concurrent change to survive, got disabled=%v version=%s", p.Disabled, p.Version)
+		}
+	})
+	t.Run("conflict", func(t *testing.T) {
+		s := &fakeConfigService{
+			config: automationConfigWithOneReplicaSet(clusterName, false),
+			concurrent: []func(*opsmngr.AutomationConfig){
+				func(c *opsmngr.AutomationConfig) { c.Processes[0].Version = "4.4.0" },
+			},
+		}
+		_, err := Update(ctx, s, groupID, func(out *opsmngr.AutomationConfig) error {
+			out.Processes[0].Version = "5.0.0"
+			return nil
+		})
+		var conflictErr *ConflictError
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("expected a *ConflictError, got %v", err)
+		}
+		expected := &Conflict{Kind: KindProcess, ID: clusterName + "_0", Path: "version", Ours: "5.0.0", Theirs: "4.4.0"}
+		if len(conflictErr.Conflicts) != 1 || *conflictErr.Conflicts[0] != *expected {
+			t.Errorf("got conflicts %+v, expected %+v", conflictErr.Conflicts, expected)
+		}
+		if s.config.Processes[0].Version != "4.4.0" {
+			t.Error("the concurrent change must not be overwritten")
+		}
+	})
+	t.Run("mutation error", func(t *testing.T) {
+		s := &fakeConfigService{config: automationConfigWithOneReplicaSet(clusterName, false)}
+		_, err := Update(ctx, s, groupID, func(out *opsmngr.AutomationConfig) error {
+			return ShutdownProcessesByClusterName(out, clusterName, []string{"unknown"})
+		})
+		if !errors.Is(err, ErrProcessNotFound) {
+			t.Errorf("expected ErrProcessNotFound, got %v", err)
+		}
+	})
+}
+
+func TestConflicts(t *testing.T) {
+	base := automationConfigWithOneReplicaSet(clusterName, false)
+
+	ours, _ := deepCopy(base)
+	RemoveByClusterName(ours, clusterName)
+	theirs, _ := deepCopy(base)
+	theirs.Processes[0].Version = "4.4.0"
+	theirs.ReplicaSets[0].Members[0].Priority = 2
+
+	expected := map[string]bool{
+		KindProcess + " " + clusterName + "_0":                    true,
+		KindReplicaSet + " " + clusterName:                        true,  // deleted by us, members changed by them
+		KindMember + " " + clusterName + "/" + clusterName + "_0": false, // reported on the replica set
+	}
+	got := map[string]bool{}
+	for _, c := range Conflicts(base, ours, theirs) {
+		got[c.Kind+" "+c.ID] = true
+	}
+	for k, want := range expected {
+		if got[k] != want {
+			t.Errorf("conflict on %s = %v, expected %v", k, got[k], want)
+		}
+	}
+}
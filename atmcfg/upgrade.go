@@ -0,0 +1,243 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+const enterpriseSuffix = "-ent"
+
+// releaseSeries are the MongoDB release series in upgrade order,
+// a binary upgrade can't skip any of them.
+var releaseSeries = []string{"3.4", "3.6", "4.0", "4.2", "4.4", "5.0", "6.0", "7.0", "8.0"}
+
+// ErrUnsupportedUpgrade means there is no supported upgrade path to the target version.
+var ErrUnsupportedUpgrade = errors.New("unsupported upgrade")
+
+// UpgradeStep is a hop of an upgrade, each step upgrades the binaries to Version
+// then sets the FeatureCompatibilityVersion once every process runs Version.
+type UpgradeStep struct {
+	Version                     string
+	FeatureCompatibilityVersion string
+}
+
+// UpgradePlan is the list of steps to upgrade the processes of a cluster to a target version.
+type UpgradePlan struct {
+	ClusterName string
+	Processes   []string
+	From        string
+	Steps       []*UpgradeStep
+}
+
+// PlanUpgrade returns the steps to upgrade the cluster clusterName to targetVersion.
+// The cluster must run a single version and can only move one release series per step,
+// intermediate steps use the latest version of their release series available in manifest,
+// which is returned by VersionManifestService.Get.
+func PlanUpgrade(out *opsmngr.AutomationConfig, clusterName, targetVersion string, manifest *opsmngr.VersionManifest) (*UpgradePlan, error) {
+	processes := clusterProcesses(out, clusterName)
+	if len(processes) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrProcessNotFound, clusterName)
+	}
+	plan := &UpgradePlan{ClusterName: clusterName, From: processes[0].Version}
+	for _, p := range processes {
+		if p.Version != plan.From {
+			return nil, fmt.Errorf("%w: %s runs %s and %s runs %s, finish the previous upgrade first", ErrUnsupportedUpgrade, processes[0].Name, plan.From, p.Name, p.Version)
+		}
+		plan.Processes = append(plan.Processes, p.Name)
+	}
+
+	available := map[string]bool{}
+	if manifest != nil {
+		for _, v := range manifest.Versions {
+			available[v.Name] = true
+		}
+	}
+	if !available[targetVersion] {
+		return nil, fmt.Errorf("%w: %s is not in the version manifest", ErrUnsupportedUpgrade, targetVersion)
+	}
+
+	from, to := seriesIndex(plan.From), seriesIndex(targetVersion)
+	if from < 0 || to < 0 {
+		return nil, fmt.Errorf("%w: from %s to %s", ErrUnsupportedUpgrade, plan.From, targetVersion)
+	}
+	if from > to || (from == to && compareVersions(plan.From, targetVersion) > 0) {
+		return nil, fmt.Errorf("%w: %s to %s is a downgrade", ErrUnsupportedUpgrade, plan.From, targetVersion)
+	}
+	currentFCV := releaseSeries[from]
+	for _, p := range processes {
+		if from < to && p.FeatureCompatibilityVersion != currentFCV {
+			return nil, fmt.Errorf("%w: %s has featureCompatibilityVersion %s, it must be %s before upgrading to the next release series", ErrUnsupportedUpgrade, p.Name, p.FeatureCompatibilityVersion, currentFCV)
+		}
+	}
+
+	suffix := ""
+	if strings.HasSuffix(targetVersion, enterpriseSuffix) {
+		suffix = enterpriseSuffix
+	}
+	for i := from + 1; i < to; i++ {
+		v := latestVersion(manifest, releaseSeries[i], suffix)
+		if v == "" {
+			return nil, fmt.Errorf("%w: no %s version in the version manifest to upgrade through", ErrUnsupportedUpgrade, releaseSeries[i])
+		}
+		plan.Steps = append(plan.Steps, &UpgradeStep{Version: v, FeatureCompatibilityVersion: releaseSeries[i]})
+	}
+	plan.Steps = append(plan.Steps, &UpgradeStep{Version: targetVersion, FeatureCompatibilityVersion: releaseSeries[to]})
+
+	return plan, nil
+}
+
+// String returns a dry run of the plan.
+func (p *UpgradePlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Upgrade %s from %s to %s (%d processes: %s)\n", p.ClusterName, p.From, p.Steps[len(p.Steps)-1].Version, len(p.Processes), strings.Join(p.Processes, ", "))
+	n := 0
+	for _, s := range p.Steps {
+		n++
+		fmt.Fprintf(&b, "  %d. set version %s\n", n, s.Version)
+		n++
+		fmt.Fprintf(&b, "  %d. wait for goal state, then set featureCompatibilityVersion %s\n", n, s.FeatureCompatibilityVersion)
+	}
+	return b.String()
+}
+
+// Apply performs the next step of the plan on out and reports whether the upgrade is complete.
+//
+// The feature compatibility version is only set once status shows every process reached the goal state
+// of the binaries upgrade, see the steps in the package documentation.
+func (p *UpgradePlan) Apply(out *opsmngr.AutomationConfig, status *opsmngr.AutomationStatus) (bool, error) {
+	processes := clusterProcesses(out, p.ClusterName)
+	if len(processes) == 0 {
+		return false, fmt.Errorf("%w: %s", ErrProcessNotFound, p.ClusterName)
+	}
+
+	for _, step := range p.Steps {
+		// steps are completed in order, so later versions mean the step is done
+		if !allProcesses(processes, func(proc *opsmngr.Process) bool {
+			return compareVersions(proc.Version, step.Version) >= 0
+		}) {
+			for _, proc := range processes {
+				proc.Version = step.Version
+			}
+			return false, nil
+		}
+		if !allProcesses(processes, func(proc *opsmngr.Process) bool {
+			return compareVersions(proc.FeatureCompatibilityVersion, step.FeatureCompatibilityVersion) >= 0
+		}) {
+			if !reachedGoal(out, status) {
+				return false, nil
+			}
+			for _, proc := range processes {
+				proc.FeatureCompatibilityVersion = step.FeatureCompatibilityVersion
+			}
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func allProcesses(processes []*opsmngr.Process, f func(*opsmngr.Process) bool) bool {
+	for _, p := range processes {
+		if !f(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// clusterProcesses returns the processes of the replica set or sharded cluster name.
+func clusterProcesses(out *opsmngr.AutomationConfig, name string) []*opsmngr.Process {
+	rsNames := map[string]bool{name: true}
+	if s, err := shardingConfig(out, name); err == nil {
+		rsNames[s.ConfigServerReplica] = true
+		for _, shard := range s.Shards {
+			rsNames[shard.RS] = true
+		}
+	}
+	members := map[string]bool{}
+	for _, rs := range out.ReplicaSets {
+		if !rsNames[rs.ID] {
+			continue
+		}
+		for _, m := range rs.Members {
+			members[m.Host] = true
+		}
+	}
+
+	var processes []*opsmngr.Process
+	for _, p := range out.Processes {
+		if members[p.Name] || p.Cluster == name {
+			processes = append(processes, p)
+		}
+	}
+	return processes
+}
+
+// seriesIndex returns the index of the release series of version in releaseSeries, or -1.
+func seriesIndex(version string) int {
+	series := featureCompatibilityVersion(version)
+	for i, s := range releaseSeries {
+		if s == series {
+			return i
+		}
+	}
+	return -1
+}
+
+// latestVersion returns the latest stable version of the release series in the manifest.
+func latestVersion(manifest *opsmngr.VersionManifest, series, suffix string) string {
+	latest := ""
+	for _, v := range manifest.Versions {
+		name := strings.TrimSuffix(v.Name, suffix)
+		if suffix != "" && name == v.Name {
+			continue
+		}
+		if featureCompatibilityVersion(name) != series || strings.ContainsAny(name, "-") {
+			continue
+		}
+		if latest == "" || compareVersions(v.Name, latest) > 0 {
+			latest = v.Name
+		}
+	}
+	return latest
+}
+
+// compareVersions compares two MongoDB versions of the form major.minor.patch.
+func compareVersions(a, b string) int {
+	pa, pb := versionNumbers(a), versionNumbers(b)
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionNumbers(v string) [3]int {
+	var n [3]int
+	v, _, _ = strings.Cut(v, "-")
+	for i, s := range strings.SplitN(v, ".", len(n)) {
+		n[i], _ = strconv.Atoi(s)
+	}
+	return n
+}
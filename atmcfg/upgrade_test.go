@@ -0,0 +1,112 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func testManifest(versions ...string) *opsmngr.VersionManifest {
+	m := &opsmngr.VersionManifest{}
+	for _, v := range versions {
+		m.Versions = append(m.Versions, &opsmngr.Version{Name: v})
+	}
+	return m
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	manifest := testManifest("4.2.2", "4.4.1", "4.4.18", "4.4.18-ent", "4.4.19-rc0", "5.0.14", "6.0.5")
+
+	t.Run("multiple hops", func(t *testing.T) {
+		out := automationConfigWithOneShardedCluster(clusterName, false)
+		plan, err := PlanUpgrade(out, clusterName, "6.0.5", manifest)
+		if err != nil {
+			t.Fatalf("PlanUpgrade() returned an error: %v", err)
+		}
+		expected := []*UpgradeStep{
+			{Version: "4.4.18", FeatureCompatibilityVersion: "4.4"},
+			{Version: "5.0.14", FeatureCompatibilityVersion: "5.0"},
+			{Version: "6.0.5", FeatureCompatibilityVersion: "6.0"},
+		}
+		if diff := deep.Equal(plan.Steps, expected); diff != nil {
+			t.Error(diff)
+		}
+		if len(plan.Processes) != 3 {
+			t.Errorf("expected the shard, config server and mongos processes, got %v", plan.Processes)
+		}
+
+		expectedPlan := `Upgrade cluster_1 from 4.2.2 to 6.0.5 (3 processes: cluster_1_shard_0_0, cluster_1_configRS_0, cluster_1_mongos_0)
+  1. set version 4.4.18
+  2. wait for goal state, then set featureCompatibilityVersion 4.4
+  3. set version 5.0.14
+  4. wait for goal state, then set featureCompatibilityVersion 5.0
+  5. set version 6.0.5
+  6. wait for goal state, then set featureCompatibilityVersion 6.0
+`
+		if s := plan.String(); s != expectedPlan {
+			t.Errorf("String() =\n%s\nexpected\n%s", s, expectedPlan)
+		}
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		out := automationConfigWithOneReplicaSet(clusterName, false)
+		for _, target := range []string{"4.0.0", "7.0.0"} {
+			if _, err := PlanUpgrade(out, clusterName, target, testManifest(target)); !errors.Is(err, ErrUnsupportedUpgrade) {
+				t.Errorf("%s: expected ErrUnsupportedUpgrade, got %v", target, err)
+			}
+		}
+
+		out.Processes[0].FeatureCompatibilityVersion = "4.0"
+		if _, err := PlanUpgrade(out, clusterName, "4.4.1", manifest); !errors.Is(err, ErrUnsupportedUpgrade) {
+			t.Errorf("expected ErrUnsupportedUpgrade for the featureCompatibilityVersion, got %v", err)
+		}
+	})
+}
+
+func TestUpgradePlan_Apply(t *testing.T) {
+	out := automationConfigWithOneReplicaSet(clusterName, false)
+	plan, err := PlanUpgrade(out, clusterName, "5.0.14", testManifest("4.4.18", "5.0.14"))
+	if err != nil {
+		t.Fatalf("PlanUpgrade() returned an error: %v", err)
+	}
+
+	p := out.Processes[0]
+	var got []string
+	for i := 0; i < 10; i++ {
+		if i == 1 {
+			// the agents didn't reach the goal state of the new version yet
+			if _, err := plan.Apply(out, statusAt(out.Version, out.Version-1)); err != nil || p.FeatureCompatibilityVersion != "4.2" {
+				t.Fatalf("expected the featureCompatibilityVersion to be kept until goal state, got %s, %v", p.FeatureCompatibilityVersion, err)
+			}
+		}
+		done, err := plan.Apply(out, statusAt(out.Version, out.Version))
+		if err != nil {
+			t.Fatalf("Apply() returned an error: %v", err)
+		}
+		if done {
+			break
+		}
+		got = append(got, p.Version+"/"+p.FeatureCompatibilityVersion)
+		out.Version++
+	}
+
+	expected := []string{"4.4.18/4.2", "4.4.18/4.4", "5.0.14/4.4", "5.0.14/5.0"}
+	if diff := deep.Equal(got, expected); diff != nil {
+		t.Error(diff)
+	}
+}
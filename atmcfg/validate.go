@@ -0,0 +1,254 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+const (
+	maxVotingMembers = 7
+	scramSha1        = "SCRAM-SHA-1"
+)
+
+// Violation is a rule broken by an automation config, Path is the JSON path of the offending field.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+func (v *Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// ValidationError lists every violation found by Validate.
+type ValidationError struct {
+	Violations []*Violation
+}
+
+func (e *ValidationError) Error() string {
+	s := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		s[i] = v.String()
+	}
+	return fmt.Sprintf("invalid automation config: %s", strings.Join(s, "; "))
+}
+
+// Validate checks an automation config for mistakes Ops Manager would reject,
+// so they can be fixed before calling AutomationService.UpdateConfig.
+// It returns a *ValidationError listing all the violations, or nil if the config is valid.
+func Validate(c *opsmngr.AutomationConfig) error {
+	if c == nil {
+		return errors.New("the Automation Config has not been initialized")
+	}
+	v := &validator{}
+	v.processes(c.Processes)
+	v.replicaSets(c.ReplicaSets, c.Processes)
+	v.sharding(c.Sharding, c.ReplicaSets)
+	v.auth(c)
+	v.roles(c)
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+	return nil
+}
+
+type validator struct {
+	violations []*Violation
+}
+
+func (v *validator) add(path, format string, a ...interface{}) {
+	v.violations = append(v.violations, &Violation{Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+func (v *validator) processes(processes []*opsmngr.Process) {
+	names := make(map[string]int, len(processes))
+	addresses := make(map[string]int, len(processes))
+	for i, p := range processes {
+		path := fmt.Sprintf("processes[%d]", i)
+		if p.Name == "" {
+			v.add(path+".name", "must be set")
+		} else if j, ok := names[p.Name]; ok {
+			v.add(path+".name", "duplicates processes[%d].name %q", j, p.Name)
+		} else {
+			names[p.Name] = i
+		}
+
+		if p.Hostname == "" {
+			v.add(path+".hostname", "must be set")
+			continue
+		}
+		port := p.Args26.NET.Port
+		if port == 0 {
+			port = defaultMongoPort
+		}
+		address := fmt.Sprintf("%s:%d", p.Hostname, port)
+		if j, ok := addresses[address]; ok {
+			v.add(path+".args2_6.net.port", "%s is already used by processes[%d]", address, j)
+		} else {
+			addresses[address] = i
+		}
+	}
+}
+
+func (v *validator) replicaSets(replicaSets []*opsmngr.ReplicaSet, processes []*opsmngr.Process) {
+	names := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		names[p.Name] = true
+	}
+
+	ids := make(map[string]int, len(replicaSets))
+	for i, rs := range replicaSets {
+		path := fmt.Sprintf("replicaSets[%d]", i)
+		if rs.ID == "" {
+			v.add(path+"._id", "must be set")
+		} else if j, ok := ids[rs.ID]; ok {
+			v.add(path+"._id", "duplicates replicaSets[%d]._id %q", j, rs.ID)
+		} else {
+			ids[rs.ID] = i
+		}
+
+		memberIDs := make(map[int]int, len(rs.Members))
+		hosts := make(map[string]int, len(rs.Members))
+		voters := 0
+		for j := range rs.Members {
+			m := &rs.Members[j]
+			memberPath := fmt.Sprintf("%s.members[%d]", path, j)
+			if k, ok := memberIDs[m.ID]; ok {
+				v.add(memberPath+"._id", "duplicates members[%d]._id %d", k, m.ID)
+			} else {
+				memberIDs[m.ID] = j
+			}
+			if k, ok := hosts[m.Host]; ok {
+				v.add(memberPath+".host", "duplicates members[%d].host %q", k, m.Host)
+			} else {
+				hosts[m.Host] = j
+			}
+			if !names[m.Host] {
+				v.add(memberPath+".host", "no process named %q", m.Host)
+			}
+			v.member(memberPath, m)
+			if m.Votes > 0 {
+				voters++
+			}
+		}
+		if voters > maxVotingMembers {
+			v.add(path+".members", "has %d voting members, at most %d are allowed", voters, maxVotingMembers)
+		}
+	}
+}
+
+func (v *validator) member(path string, m *opsmngr.Member) {
+	if m.Votes != 0 && m.Votes != 1 {
+		v.add(path+".votes", "must be 0 or 1")
+	}
+	if m.Priority == 0 {
+		return
+	}
+	switch {
+	case m.ArbiterOnly:
+		v.add(path+".priority", "must be 0 for arbiters")
+	case m.Hidden:
+		v.add(path+".priority", "must be 0 for hidden members")
+	case m.Votes == 0:
+		v.add(path+".priority", "must be 0 for non-voting members")
+	}
+}
+
+func (v *validator) sharding(sharding []*opsmngr.ShardingConfig, replicaSets []*opsmngr.ReplicaSet) {
+	ids := make(map[string]bool, len(replicaSets))
+	for _, rs := range replicaSets {
+		ids[rs.ID] = true
+	}
+
+	for i, s := range sharding {
+		path := fmt.Sprintf("sharding[%d]", i)
+		if !ids[s.ConfigServerReplica] {
+			v.add(path+".configServerReplica", "no replica set with _id %q", s.ConfigServerReplica)
+		}
+		for j, shard := range s.Shards {
+			if !ids[shard.RS] {
+				v.add(fmt.Sprintf("%s.shards[%d].rs", path, j), "no replica set with _id %q", shard.RS)
+			}
+		}
+	}
+}
+
+func (v *validator) auth(c *opsmngr.AutomationConfig) {
+	a := &c.Auth
+	if a.Disabled {
+		return
+	}
+	if a.Keyfile == "" {
+		v.add("auth.keyfile", "must be set when auth is enabled")
+	}
+	if a.KeyfileWindows == "" {
+		v.add("auth.keyfileWindows", "must be set when auth is enabled")
+	}
+	if a.Key == "" {
+		v.add("auth.key", "must be set when auth is enabled")
+	}
+	if stringInSlice(a.DeploymentAuthMechanisms, plain) && (c.LDAP == nil || c.LDAP.Servers == "") {
+		v.add("ldap.servers", "must be set when %s is enabled", plain)
+	}
+	if stringInSlice(a.DeploymentAuthMechanisms, gssapi) && (c.Kerberos == nil || c.Kerberos.ServiceName == "") {
+		v.add("kerberos.serviceName", "must be set when %s is enabled", gssapi)
+	}
+
+	if a.AutoAuthMechanism != "" && a.AutoUser == "" {
+		v.add("auth.autoUser", "must be set when auth is enabled with %s", a.AutoAuthMechanism)
+	}
+	switch a.AutoAuthMechanism {
+	case mongoCR, scramSha1, scramSha256, plain:
+		if a.AutoPwd == "" {
+			v.add("auth.autoPwd", "must be set when auth is enabled with %s", a.AutoAuthMechanism)
+		}
+	case gssapi:
+		if a.AutoKerberosKeytabPath == "" {
+			v.add("auth.autoKerberosKeytabPath", "must be set when auth is enabled with %s", a.AutoAuthMechanism)
+		}
+	case x509:
+		// the agent certificate may be in the ssl options of configs predating tls
+		if (c.TLS == nil || c.TLS.AutoPEMKeyFilePath == "") && (c.SSL == nil || c.SSL.AutoPEMKeyFilePath == "") {
+			v.add("tls.autoPEMKeyFilePath", "must be set when auth is enabled with %s", a.AutoAuthMechanism)
+		}
+	}
+	if a.NewAutoPwd != "" && a.AutoPwd == "" {
+		v.add("auth.newAutoPwd", "requires auth.autoPwd to be set")
+	}
+}
+
+// roles checks every role granted to a user or inherited by a custom role is a built-in or custom role.
+func (v *validator) roles(c *opsmngr.AutomationConfig) {
+	for i, u := range c.Auth.UsersWanted {
+		for j, r := range u.Roles {
+			if !resolveRole(c.Roles, r) {
+				v.add(fmt.Sprintf("auth.usersWanted[%d].roles[%d]", i, j), "no built-in or custom role %s", qualifiedName(r.Role, r.Database))
+			}
+		}
+	}
+	for i, cr := range c.Roles {
+		for j, r := range cr.Roles {
+			if !resolveRole(c.Roles, r) {
+				v.add(fmt.Sprintf("roles[%d].roles[%d]", i, j), "no built-in or custom role %s", qualifiedName(r.Role, r.Database))
+			}
+		}
+	}
+}
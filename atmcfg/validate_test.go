@@ -0,0 +1,162 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, config := range []*opsmngr.AutomationConfig{
+			automationConfigWithOneReplicaSet(clusterName, false),
+			automationConfigWithOneShardedCluster(clusterName, false),
+			automationConfigWithThreeShardsCluster(clusterName, false),
+		} {
+			config.Auth.Disabled = true
+			if err := Validate(config); err != nil {
+				t.Errorf("Validate() returned an error: %v", err)
+			}
+		}
+	})
+	t.Run("auth enabled", func(t *testing.T) {
+		config := automationConfigWithOneReplicaSet(clusterName, false)
+		if err := EnableMechanism(config, []string{scramSha256}); err != nil {
+			t.Fatalf("EnableMechanism() returned an error: %v", err)
+		}
+		if err := Validate(config); err != nil {
+			t.Errorf("Validate() returned an error: %v", err)
+		}
+
+		config.Auth.AutoPwd = ""
+		config.Auth.Keyfile = ""
+		assertViolations(t, Validate(config), []*Violation{
+			{Path: "auth.keyfile", Message: "must be set when auth is enabled"},
+			{Path: "auth.autoPwd", Message: "must be set when auth is enabled with SCRAM-SHA-256"},
+		})
+	})
+	t.Run("auth enabled with GSSAPI", func(t *testing.T) {
+		config := automationConfigWithOneReplicaSet(clusterName, false)
+		err := EnableMechanism(config, []string{gssapi},
+			WithKerberos(&opsmngr.Kerberos{ServiceName: "mongodb"}),
+			WithKerberosAgent("mms-automation@EXAMPLE.COM", "/etc/mms-automation.keytab"))
+		if err != nil {
+			t.Fatalf("EnableMechanism() returned an error: %v", err)
+		}
+		if err := Validate(config); err != nil {
+			t.Errorf("Validate() returned an error: %v", err)
+		}
+
+		config.Kerberos = nil
+		config.Auth.AutoKerberosKeytabPath = ""
+		assertViolations(t, Validate(config), []*Violation{
+			{Path: "kerberos.serviceName", Message: "must be set when GSSAPI is enabled"},
+			{Path: "auth.autoKerberosKeytabPath", Message: "must be set when auth is enabled with GSSAPI"},
+		})
+	})
+	t.Run("auth enabled with MONGODB-X509", func(t *testing.T) {
+		config := automationConfigWithOneReplicaSet(clusterName, false)
+		config.TLS = &opsmngr.SSL{CAFilePath: "/etc/ca.pem"}
+		err := EnableMechanism(config, []string{x509}, WithX509Agent("CN=mms-automation", "/etc/mms-automation.pem"))
+		if err != nil {
+			t.Fatalf("EnableMechanism() returned an error: %v", err)
+		}
+		if err := Validate(config); err != nil {
+			t.Errorf("Validate() returned an error: %v", err)
+		}
+
+		config.SSL, config.TLS = config.TLS, nil
+		if err := Validate(config); err != nil {
+			t.Errorf("Validate() returned an error with the deprecated ssl options: %v", err)
+		}
+
+		config.SSL = nil
+		assertViolations(t, Validate(config), []*Violation{
+			{Path: "tls.autoPEMKeyFilePath", Message: "must be set when auth is enabled with MONGODB-X509"},
+		})
+	})
+	t.Run("nil config", func(t *testing.T) {
+		if err := Validate(nil); err == nil {
+			t.Error("Validate() expected an error")
+		}
+	})
+	t.Run("roles", func(t *testing.T) {
+		config := automationConfigWithMongoDBUsers()
+		config.Auth.UsersWanted[0].Roles = append(config.Auth.UsersWanted[0].Roles, &opsmngr.Role{Role: "readAnyDatabase", Database: "admin"})
+		assertViolations(t, Validate(config), []*Violation{
+			{Path: "auth.usersWanted[0].roles[0]", Message: "no built-in or custom role test@test"},
+		})
+	})
+	t.Run("replica set", func(t *testing.T) {
+		config := automationConfigWithOneReplicaSet(clusterName, false)
+		config.Auth.Disabled = true
+		p := *config.Processes[0]
+		p.Name = clusterName + "_1"
+		config.Processes = append(config.Processes, &p)
+		config.ReplicaSets[0].Members = append(config.ReplicaSets[0].Members,
+			opsmngr.Member{ID: 0, Host: clusterName + "_1", ArbiterOnly: true, Priority: 1, Votes: 1},
+			opsmngr.Member{ID: 2, Host: "unknown", Votes: 2},
+		)
+
+		assertViolations(t, Validate(config), []*Violation{
+			{Path: "processes[1].args2_6.net.port", Message: "host0:27017 is already used by processes[0]"},
+			{Path: "replicaSets[0].members[1]._id", Message: "duplicates members[0]._id 0"},
+			{Path: "replicaSets[0].members[1].priority", Message: "must be 0 for arbiters"},
+			{Path: "replicaSets[0].members[2].host", Message: `no process named "unknown"`},
+			{Path: "replicaSets[0].members[2].votes", Message: "must be 0 or 1"},
+		})
+	})
+	t.Run("too many voters", func(t *testing.T) {
+		config := automationConfigWithOneReplicaSet(clusterName, false)
+		config.Auth.Disabled = true
+		config.ReplicaSets[0].Members = nil
+		config.Processes = nil
+		for i := 0; i < 8; i++ {
+			name := clusterName + "_" + string(rune('0'+i))
+			config.Processes = append(config.Processes, &opsmngr.Process{Name: name, Hostname: name})
+			config.ReplicaSets[0].Members = append(config.ReplicaSets[0].Members, opsmngr.Member{ID: i, Host: name, Votes: 1, Priority: 1})
+		}
+
+		assertViolations(t, Validate(config), []*Violation{
+			{Path: "replicaSets[0].members", Message: "has 8 voting members, at most 7 are allowed"},
+		})
+	})
+	t.Run("sharding", func(t *testing.T) {
+		config := automationConfigWithOneShardedCluster(clusterName, false)
+		config.Auth.Disabled = true
+		config.Sharding[0].ConfigServerReplica = "unknown"
+		config.Sharding[0].Shards[0].RS = "unknown"
+
+		assertViolations(t, Validate(config), []*Violation{
+			{Path: "sharding[0].configServerReplica", Message: `no replica set with _id "unknown"`},
+			{Path: "sharding[0].shards[0].rs", Message: `no replica set with _id "unknown"`},
+		})
+	})
+}
+
+func assertViolations(t *testing.T, err error, expected []*Violation) {
+	t.Helper()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+	if diff := deep.Equal(validationErr.Violations, expected); diff != nil {
+		t.Error(diff)
+	}
+}
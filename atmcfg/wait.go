@@ -0,0 +1,195 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+const (
+	defaultWaitInterval = 5 * time.Second
+	maxPlanSteps        = 3
+)
+
+// ErrProcessFailed means the automation agent reported an error for a process.
+var ErrProcessFailed = errors.New("automation reported an error")
+
+// StatusService is the part of opsmngr.AutomationService used by WaitForGoalState.
+type StatusService interface {
+	GetStatus(context.Context, string) (*opsmngr.AutomationStatus, *opsmngr.Response, error)
+}
+
+var _ StatusService = &opsmngr.AutomationServiceOp{}
+
+// WaitOptions configures WaitForGoalState.
+type WaitOptions struct {
+	// Interval between polls, defaults to 5s.
+	Interval time.Duration
+	// Multiplier applied to the interval after every poll, no backoff if less than 1.
+	Multiplier float64
+	// MaxInterval caps the interval when using a Multiplier.
+	MaxInterval time.Duration
+	// Progress is called with the status of each process after every poll.
+	Progress func(goalVersion int, processes []*ProcessProgress)
+}
+
+// ProcessProgress is the progress of a process towards the goal version.
+type ProcessProgress struct {
+	Name                    string
+	Hostname                string
+	LastGoalVersionAchieved int
+	GoalVersion             int
+	Plan                    []string // Plan are the steps the agent still has to perform
+	Error                   string   // Error reported by the agent, if any
+}
+
+// Done reports whether the process reached the goal version.
+func (p *ProcessProgress) Done() bool {
+	return p.LastGoalVersionAchieved == p.GoalVersion
+}
+
+func (p *ProcessProgress) String() string {
+	s := fmt.Sprintf("%s (%s) at version %d of %d", p.Name, p.Hostname, p.LastGoalVersionAchieved, p.GoalVersion)
+	if len(p.Plan) > 0 {
+		plan := p.Plan
+		if len(plan) > maxPlanSteps {
+			plan = append(plan[:maxPlanSteps:maxPlanSteps], "...")
+		}
+		s += ", plan: " + strings.Join(plan, ", ")
+	}
+	if p.Error != "" {
+		s += ", error: " + p.Error
+	}
+	return s
+}
+
+// GoalStateError is returned by WaitForGoalState when the goal state is not reached,
+// it lists the processes that are stuck.
+type GoalStateError struct {
+	GoalVersion int
+	Processes   []*ProcessProgress
+	Err         error // Err is ErrProcessFailed or the error of the context
+}
+
+func (e *GoalStateError) Error() string {
+	s := make([]string, len(e.Processes))
+	for i, p := range e.Processes {
+		s[i] = p.String()
+	}
+	return fmt.Sprintf("goal version %d not reached: %v: %s", e.GoalVersion, e.Err, strings.Join(s, "; "))
+}
+
+func (e *GoalStateError) Unwrap() error {
+	return e.Err
+}
+
+// WaitForGoalState polls the automation status of the project until every process
+// reaches the goal version, for example after AutomationService.UpdateConfig.
+// It fails early if the agent reports an error for any process.
+// When ctx is done it returns a *GoalStateError wrapping ctx.Err().
+func WaitForGoalState(ctx context.Context, s StatusService, groupID string, opts *WaitOptions) (*opsmngr.AutomationStatus, error) {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	var stuck []*ProcessProgress
+	goalVersion := 0
+	for {
+		status, _, err := s.GetStatus(ctx, groupID)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil && stuck != nil {
+				return nil, &GoalStateError{GoalVersion: goalVersion, Processes: stuck, Err: ctxErr}
+			}
+			return nil, err
+		}
+
+		goalVersion = status.GoalVersion
+		processes := processesProgress(status)
+		if opts.Progress != nil {
+			opts.Progress(status.GoalVersion, processes)
+		}
+
+		stuck = nil
+		var failed []*ProcessProgress
+		for _, p := range processes {
+			if p.Error != "" {
+				failed = append(failed, p)
+			}
+			if !p.Done() {
+				stuck = append(stuck, p)
+			}
+		}
+		if len(failed) > 0 {
+			return status, &GoalStateError{GoalVersion: goalVersion, Processes: failed, Err: ErrProcessFailed}
+		}
+		if len(stuck) == 0 {
+			return status, nil
+		}
+
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return status, &GoalStateError{GoalVersion: goalVersion, Processes: stuck, Err: ctx.Err()}
+		case <-t.C:
+		}
+
+		if opts.Multiplier > 1 {
+			interval = time.Duration(float64(interval) * opts.Multiplier)
+			if opts.MaxInterval > 0 && interval > opts.MaxInterval {
+				interval = opts.MaxInterval
+			}
+		}
+	}
+}
+
+func processesProgress(s *opsmngr.AutomationStatus) []*ProcessProgress {
+	processes := make([]*ProcessProgress, len(s.Processes))
+	for i := range s.Processes {
+		p := &s.Processes[i]
+		processes[i] = &ProcessProgress{
+			Name:                    p.Name,
+			Hostname:                p.Hostname,
+			LastGoalVersionAchieved: p.LastGoalVersionAchieved,
+			GoalVersion:             s.GoalVersion,
+			Plan:                    p.Plan,
+			Error:                   processError(p),
+		}
+	}
+	return processes
+}
+
+func processError(p *opsmngr.ProcessStatus) string {
+	if p.ErrorString != nil && *p.ErrorString != "" {
+		return *p.ErrorString
+	}
+	if p.ErrorCodeHumanReadable != nil && *p.ErrorCodeHumanReadable != "" {
+		return *p.ErrorCodeHumanReadable
+	}
+	if p.ErrorCode != nil && *p.ErrorCode != 0 {
+		return fmt.Sprintf("error code %d", *p.ErrorCode)
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atmcfg
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+// fakeStatusService returns statuses in order, repeating the last one.
+type fakeStatusService struct {
+	statuses []*opsmngr.AutomationStatus
+	polls    int
+}
+
+func (s *fakeStatusService) GetStatus(context.Context, string) (*opsmngr.AutomationStatus, *opsmngr.Response, error) {
+	i := s.polls
+	if i >= len(s.statuses) {
+		i = len(s.statuses) - 1
+	}
+	s.polls++
+	return s.statuses[i], nil, nil
+}
+
+func statusAt(goal int, achieved ...int) *opsmngr.AutomationStatus {
+	s := &opsmngr.AutomationStatus{GoalVersion: goal}
+	for i, v := range achieved {
+		s.Processes = append(s.Processes, opsmngr.ProcessStatus{
+			Name:                    clusterName + "_" + string(rune('0'+i)),
+			Hostname:                "host" + string(rune('0'+i)),
+			LastGoalVersionAchieved: v,
+			Plan:                    []string{"Download", "Start"},
+		})
+	}
+	return s
+}
+
+func TestWaitForGoalState(t *testing.T) {
+	ctx := context.Background()
+	opts := &WaitOptions{Interval: time.Millisecond, Multiplier: 2, MaxInterval: 4 * time.Millisecond}
+
+	t.Run("reached", func(t *testing.T) {
+		s := &fakeStatusService{statuses: []*opsmngr.AutomationStatus{statusAt(2, 1, 1), statusAt(2, 2, 1), statusAt(2, 2, 2)}}
+		var progress []int
+		o := *opts
+		o.Progress = func(_ int, processes []*ProcessProgress) {
+			done := 0
+			for _, p := range processes {
+				if p.Done() {
+					done++
+				}
+			}
+			progress = append(progress, done)
+		}
+		if _, err := WaitForGoalState(ctx, s, groupID, &o); err != nil {
+			t.Fatalf("WaitForGoalState() returned an error: %v", err)
+		}
+		if s.polls != 3 || len(progress) != 3 || progress[2] != 2 {
+			t.Errorf("got %d polls and progress %v", s.polls, progress)
+		}
+	})
+	t.Run("process error", func(t *testing.T) {
+		failed := statusAt(2, 1, 1)
+		errString := "failed to download"
+		failed.Processes[1].ErrorString = &errString
+		s := &fakeStatusService{statuses: []*opsmngr.AutomationStatus{failed}}
+
+		_, err := WaitForGoalState(ctx, s, groupID, opts)
+		var goalErr *GoalStateError
+		if !errors.As(err, &goalErr) || !errors.Is(err, ErrProcessFailed) {
+			t.Fatalf("expected a *GoalStateError wrapping ErrProcessFailed, got %v", err)
+		}
+		if len(goalErr.Processes) != 1 || goalErr.Processes[0].Name != clusterName+"_1" {
+			t.Errorf("expected the failed process only, got %v", goalErr.Processes)
+		}
+		if !strings.Contains(err.Error(), errString) {
+			t.Errorf("expected the error to contain %q, got %q", errString, err)
+		}
+	})
+	t.Run("timeout", func(t *testing.T) {
+		s := &fakeStatusService{statuses: []*opsmngr.AutomationStatus{statusAt(2, 2, 1)}}
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		_, err := WaitForGoalState(ctx, s, groupID, opts)
+		var goalErr *GoalStateError
+		if !errors.As(err, &goalErr) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a *GoalStateError wrapping context.DeadlineExceeded, got %v", err)
+		}
+		if len(goalErr.Processes) != 1 || goalErr.Processes[0].Name != clusterName+"_1" {
+			t.Errorf("expected the stuck process only, got %v", goalErr.Processes)
+		}
+	})
+}
@@ -17,6 +17,7 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -25,8 +26,6 @@ import (
 	"go.mongodb.org/ops-manager/opsmngr"
 )
 
-const authExpiredError = "DEVICE_AUTHORIZATION_EXPIRED"
-
 // DeviceCode holds information about the authorization-in-progress.
 type DeviceCode struct {
 	UserCode        string `json:"user_code"`        //nolint:tagliatelle // UserCode is the code presented to users
@@ -85,6 +84,7 @@ func (c *Config) GetToken(ctx context.Context, deviceCode string) (*Token, *opsm
 var ErrTimeout = errors.New("authentication timed out")
 
 // PollToken polls the server until an access token is granted or denied.
+// The granted token is persisted when the Config has a TokenStore.
 func (c *Config) PollToken(ctx context.Context, code *DeviceCode) (*Token, *opsmngr.Response, error) {
 	timeNow := code.timeNow
 	if timeNow == nil {
@@ -101,8 +101,7 @@ func (c *Config) PollToken(ctx context.Context, code *DeviceCode) (*Token, *opsm
 	for {
 		timeSleep(checkInterval)
 		token, resp, err := c.GetToken(ctx, code.DeviceCode)
-		var target *opsmngr.ErrorResponse
-		if errors.As(err, &target) && target.ErrorCode == "DEVICE_AUTHORIZATION_PENDING" {
+		if errors.Is(err, opsmngr.ErrDeviceAuthorizationPending) {
 			continue
 		}
 		if err != nil {
@@ -112,6 +111,9 @@ func (c *Config) PollToken(ctx context.Context, code *DeviceCode) (*Token, *opsm
 		if timeNow().After(expiresAt) {
 			return nil, nil, ErrTimeout
 		}
+		if err := c.saveToken(token); err != nil {
+			return nil, resp, fmt.Errorf("error saving token: %w", err)
+		}
 		return token, resp, nil
 	}
 }
@@ -169,6 +171,5 @@ func (c *Config) RegistrationConfig(ctx context.Context) (*RegistrationConfig, *
 
 // IsTimeoutErr checks if the given error is for the case where the device flow has expired.
 func IsTimeoutErr(err error) bool {
-	var target *opsmngr.ErrorResponse
-	return errors.Is(err, ErrTimeout) || (errors.As(err, &target) && target.ErrorCode == authExpiredError)
+	return errors.Is(err, ErrTimeout) || errors.Is(err, opsmngr.ErrDeviceAuthorizationExpired)
 }
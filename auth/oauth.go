@@ -44,6 +44,9 @@ type Config struct {
 
 	// copy raw server response to the Response struct
 	withRaw bool
+
+	// persist tokens between sessions
+	tokenStore TokenStore
 }
 
 type ConfigOpt func(*Config) error
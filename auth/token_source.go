@@ -0,0 +1,91 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoRefreshToken means the token expired and can't be refreshed.
+var ErrNoRefreshToken = errors.New("token expired and refresh token is not set")
+
+// refreshTokenSource is a TokenSource that refreshes the token with the device flow
+// token endpoint once the token is no longer valid.
+type refreshTokenSource struct {
+	ctx    context.Context
+	config *Config
+
+	mu sync.Mutex // guards t, held during a refresh so concurrent callers wait for a single refresh
+	t  *Token
+}
+
+var _ TokenSource = &refreshTokenSource{}
+
+// TokenSource returns a TokenSource that returns t until it expires,
+// then refreshes it using its RefreshToken. The returned TokenSource is safe for concurrent use.
+// The given ctx is used for refreshing the token.
+func (c *Config) TokenSource(ctx context.Context, t *Token) TokenSource {
+	if t != nil {
+		// tokens just returned by the device flow only carry ExpiresIn
+		tt := *t
+		tt.setExpiry()
+		t = &tt
+	}
+	return &refreshTokenSource{
+		ctx:    ctx,
+		config: c,
+		t:      t,
+	}
+}
+
+// Token returns the current token, refreshing it if it's no longer valid.
+func (s *refreshTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.t.Valid() {
+		return s.t, nil
+	}
+	if s.t == nil || s.t.RefreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+
+	t, _, err := s.config.RefreshToken(s.ctx, s.t.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if t.RefreshToken == "" {
+		// the refresh token can be reused when the server doesn't rotate it
+		t.RefreshToken = s.t.RefreshToken
+	}
+	t.setExpiry()
+	s.t = t
+	if err := s.config.saveToken(t); err != nil {
+		return nil, fmt.Errorf("error saving refreshed token: %w", err)
+	}
+
+	return t, nil
+}
+
+// setExpiry sets Expiry from ExpiresIn for tokens just returned by the token endpoint.
+func (t *Token) setExpiry() {
+	if t.Expiry.IsZero() && t.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+}
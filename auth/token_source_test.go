@@ -0,0 +1,114 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func handleRefreshToken(t *testing.T, mux *http.ServeMux, refreshes *int32) {
+	t.Helper()
+	mux.HandleFunc("/api/private/unauth/account/device/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r)
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm returned error: %v", err)
+		}
+		if got := r.PostForm.Get("refresh_token"); got != "refresh" {
+			t.Errorf("refresh_token = %v, expected %v", got, "refresh")
+		}
+		n := atomic.AddInt32(refreshes, 1)
+		_, _ = fmt.Fprintf(w, `{
+		  "access_token": "access%d",
+		  "scope": "openid",
+		  "token_type": "Bearer",
+		  "expires_in": 3600
+		}`, n)
+	})
+}
+
+func TestConfig_TokenSource(t *testing.T) {
+	config, mux, teardown := setup()
+	defer teardown()
+
+	var refreshes int32
+	handleRefreshToken(t, mux, &refreshes)
+
+	expired := &Token{
+		AccessToken:  "access0",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Hour),
+	}
+	source := config.TokenSource(ctx, expired)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := source.Token()
+			if err != nil {
+				t.Errorf("Token returned error: %v", err)
+				return
+			}
+			if token.AccessToken != "access1" {
+				t.Errorf("AccessToken = %v, expected %v", token.AccessToken, "access1")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes != 1 {
+		t.Errorf("token refreshed %d times, expected a single refresh", refreshes)
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if token.RefreshToken != "refresh" {
+		t.Errorf("RefreshToken = %v, expected the previous refresh token to be kept", token.RefreshToken)
+	}
+	if !token.Valid() || token.Expiry.IsZero() {
+		t.Errorf("expected a valid token with an expiry, got %+v", token)
+	}
+}
+
+func TestConfig_TokenSource_valid(t *testing.T) {
+	config := NewConfig(nil)
+	token := &Token{AccessToken: "access", ExpiresIn: 3600}
+
+	got, err := config.TokenSource(ctx, token).Token()
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if got.AccessToken != token.AccessToken || got.Expiry.IsZero() {
+		t.Errorf("expected the token with an expiry, got %+v", got)
+	}
+}
+
+func TestConfig_TokenSource_noRefreshToken(t *testing.T) {
+	config := NewConfig(nil)
+	token := &Token{AccessToken: "access", Expiry: time.Now().Add(-time.Hour)}
+
+	if _, err := config.TokenSource(ctx, token).Token(); !errors.Is(err, ErrNoRefreshToken) {
+		t.Errorf("expected %v, got %v", ErrNoRefreshToken, err)
+	}
+}
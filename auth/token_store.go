@@ -0,0 +1,241 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	tokenFilePerm = 0600
+	tokenDirPerm  = 0700
+)
+
+// ErrTokenNotFound means the store has no token for the given key.
+var ErrTokenNotFound = errors.New("token not found")
+
+// TokenStore persists tokens so users don't need to log in again on every run.
+// Tokens are keyed by the auth URL and client ID of the Config they were issued for.
+// Implementations must be safe for concurrent use by multiple goroutines.
+type TokenStore interface {
+	// Load returns the token for key or ErrTokenNotFound.
+	Load(key string) (*Token, error)
+	// Save stores t for key, replacing any previous token.
+	Save(key string, t *Token) error
+	// Delete removes the token for key, it's not an error if there is none.
+	Delete(key string) error
+}
+
+// SetTokenStore is a config option for persisting tokens obtained with PollToken
+// and every token refreshed by a TokenSource of the Config.
+func SetTokenStore(s TokenStore) ConfigOpt {
+	return func(c *Config) error {
+		c.tokenStore = s
+		return nil
+	}
+}
+
+// TokenKey returns the key identifying the tokens of the Config in a TokenStore.
+func (c *Config) TokenKey() string {
+	return c.AuthURL.String() + "#" + c.ClientID
+}
+
+// LoadToken returns the token persisted for the Config.
+func (c *Config) LoadToken() (*Token, error) {
+	if c.tokenStore == nil {
+		return nil, ErrTokenNotFound
+	}
+	return c.tokenStore.Load(c.TokenKey())
+}
+
+// DeleteToken removes the token persisted for the Config, for example after revoking it.
+func (c *Config) DeleteToken() error {
+	if c.tokenStore == nil {
+		return nil
+	}
+	return c.tokenStore.Delete(c.TokenKey())
+}
+
+// saveToken persists a copy of t with its expiry set, if the Config has a TokenStore.
+func (c *Config) saveToken(t *Token) error {
+	if c.tokenStore == nil || t == nil {
+		return nil
+	}
+	tt := *t
+	tt.setExpiry()
+	return c.tokenStore.Save(c.TokenKey(), &tt)
+}
+
+// MemoryTokenStore is a TokenStore keeping tokens in memory.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+var _ TokenStore = &MemoryTokenStore{}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[string]Token{}}
+}
+
+// Load returns a copy of the token for key.
+func (s *MemoryTokenStore) Load(key string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tokens[key]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return &t, nil
+}
+
+// Save stores a copy of t for key.
+func (s *MemoryTokenStore) Save(key string, t *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = *t
+	return nil
+}
+
+// Delete removes the token for key.
+func (s *MemoryTokenStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tokens, key)
+	return nil
+}
+
+// FileTokenStore is a TokenStore keeping tokens in a JSON file only readable by the current user.
+// The file is replaced atomically on every change so it's never left half written.
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+var _ TokenStore = &FileTokenStore{}
+
+// NewFileTokenStore returns a FileTokenStore for the file at path,
+// the file and its directory are created on the first Save.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// Load returns the token for key.
+func (s *FileTokenStore) Load(key string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := tokens[key]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return t, nil
+}
+
+// Save stores t for key.
+func (s *FileTokenStore) Save(key string, t *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	tokens[key] = t
+	return s.write(tokens)
+}
+
+// Delete removes the token for key.
+func (s *FileTokenStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[key]; !ok {
+		return nil
+	}
+	delete(tokens, key)
+	return s.write(tokens)
+}
+
+func (s *FileTokenStore) read() (map[string]*Token, error) {
+	tokens := map[string]*Token{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("error reading token store %s: %w", s.path, err)
+	}
+	return tokens, nil
+}
+
+func (s *FileTokenStore) write(tokens map[string]*Token) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, tokenDirPerm); err != nil {
+		return err
+	}
+	// CreateTemp creates the file with 0600 permissions
+	f, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp) // no-op once renamed
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, tokenFilePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func testTokenStore(t *testing.T, s TokenStore) {
+	t.Helper()
+
+	if _, err := s.Load("a"); !errors.Is(err, ErrTokenNotFound) {
+		t.Fatalf("expected %v, got %v", ErrTokenNotFound, err)
+	}
+
+	expiry := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tokenA := &Token{AccessToken: "access", RefreshToken: "refresh", Expiry: expiry}
+	tokenB := &Token{AccessToken: "other"}
+	if err := s.Save("a", tokenA); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := s.Save("b", tokenB); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	got, err := s.Load("a")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if diff := deep.Equal(got, tokenA); diff != nil {
+		t.Error(diff)
+	}
+
+	if err = s.Delete("a"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err = s.Delete("a"); err != nil {
+		t.Fatalf("Delete returned error for a missing token: %v", err)
+	}
+	if _, err = s.Load("a"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected %v, got %v", ErrTokenNotFound, err)
+	}
+	if got, err = s.Load("b"); err != nil || got.AccessToken != tokenB.AccessToken {
+		t.Errorf("expected the other token to be kept, got %v %v", got, err)
+	}
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	testTokenStore(t, NewMemoryTokenStore())
+}
+
+func TestFileTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "tokens.json")
+	testTokenStore(t, NewFileTokenStore(path))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat returned error: %v", err)
+	}
+	if perm := info.Mode().Perm(); runtime.GOOS != "windows" && perm != tokenFilePerm {
+		t.Errorf("file permissions = %v, expected %v", perm, os.FileMode(tokenFilePerm))
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected temporary files to be removed, got %d files", len(entries))
+	}
+}
+
+func TestConfig_TokenSource_persistsRefreshedToken(t *testing.T) {
+	config, mux, teardown := setup()
+	defer teardown()
+	store := NewMemoryTokenStore()
+	config.ClientID = "client"
+	if err := SetTokenStore(store)(config); err != nil {
+		t.Fatalf("SetTokenStore returned error: %v", err)
+	}
+
+	var refreshes int32
+	handleRefreshToken(t, mux, &refreshes)
+
+	expired := &Token{AccessToken: "access0", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)}
+	if _, err := config.TokenSource(ctx, expired).Token(); err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+
+	saved, err := config.LoadToken()
+	if err != nil {
+		t.Fatalf("LoadToken returned error: %v", err)
+	}
+	if saved.AccessToken != "access1" || saved.RefreshToken != "refresh" || saved.Expiry.IsZero() {
+		t.Errorf("expected the refreshed token to be saved, got %+v", saved)
+	}
+
+	if err = config.DeleteToken(); err != nil {
+		t.Fatalf("DeleteToken returned error: %v", err)
+	}
+	if _, err = store.Load(config.TokenKey()); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected %v, got %v", ErrTokenNotFound, err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Transport is an http.RoundTripper that authenticates every request with a token from Source.
+type Transport struct {
+	// Source supplies the token to add to requests.
+	Source TokenSource
+	// Base is the underlying http.RoundTripper, http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+}
+
+var _ http.RoundTripper = &Transport{}
+
+// RoundTrip authorizes and sends the request.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Source == nil {
+		closeBody(req)
+		return nil, errors.New("auth: Transport's Source is nil")
+	}
+	token, err := t.Source.Token()
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
+
+	// a RoundTripper must not modify the request
+	req2 := req.Clone(req.Context())
+	token.SetAuthHeader(req2)
+
+	return t.base().RoundTrip(req2)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// Client returns an *http.Client that authenticates requests with t,
+// refreshing it once expired. The client can be passed to opsmngr.NewClient.
+func (c *Config) Client(ctx context.Context, t *Token) *http.Client {
+	return &http.Client{
+		Transport: &Transport{
+			Source: c.TokenSource(ctx, t),
+		},
+	}
+}
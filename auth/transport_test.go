@@ -0,0 +1,73 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+func TestConfig_Client(t *testing.T) {
+	config, mux, teardown := setup()
+	defer teardown()
+
+	var refreshes int32
+	handleRefreshToken(t, mux, &refreshes)
+	mux.HandleFunc("/api/public/v1.0/groups/1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer access1" {
+			t.Errorf("Authorization = %v, expected %v", got, "Bearer access1")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	token := &Token{AccessToken: "access0", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)}
+	client, err := opsmngr.New(config.Client(ctx, token), opsmngr.SetBaseURL(config.AuthURL.String()))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err = client.Projects.Get(ctx, "1"); err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("token refreshed %d times, expected %d", refreshes, 1)
+	}
+}
+
+type errTokenSource struct{}
+
+var errToken = errors.New("no token")
+
+func (errTokenSource) Token() (*Token, error) {
+	return nil, errToken
+}
+
+func TestTransport_tokenError(t *testing.T) {
+	client := &http.Client{Transport: &Transport{Source: errTokenSource{}}}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", http.NoBody)
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, errToken) {
+		t.Errorf("expected %v, got %v", errToken, err)
+	}
+}
@@ -1,6 +1,6 @@
 module go.mongodb.org/ops-manager
 
-go 1.18
+go 1.21
 
 require (
 	github.com/go-test/deep v1.1.1
@@ -154,6 +154,10 @@ func (s *AgentsServiceOp) ListAgentsByType(ctx context.Context, groupID, agentTy
 		return nil, resp, err
 	}
 
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
 	return root, resp, err
 }
 
@@ -77,8 +77,8 @@ type AutomationConfig struct {
 	CPSModules                []*map[string]interface{} `json:"cpsModules"`
 	DBCheckModules            []*map[string]interface{} `json:"dbCheckModules,omitempty"`
 	IndexConfigs              []*IndexConfig            `json:"indexConfigs"`
-	Kerberos                  *map[string]interface{}   `json:"kerberos,omitempty"`
-	LDAP                      *map[string]interface{}   `json:"ldap,omitempty"`
+	Kerberos                  *Kerberos                 `json:"kerberos,omitempty"`
+	LDAP                      *LDAP                     `json:"ldap,omitempty"`
 	MaintainedEnvoys          []*map[string]interface{} `json:"maintainedEnvoys,omitempty"`
 	MongoDBToolsVersion       *map[string]interface{}   `json:"mongoDbToolsVersion,omitempty"`
 	MongoDBVersions           []*map[string]interface{} `json:"mongoDbVersions,omitempty"`
@@ -90,7 +90,7 @@ type AutomationConfig struct {
 	Processes                 []*Process                `json:"processes"`
 	Prometheus                *Prometheus               `json:"prometheus,omitempty"`
 	ReplicaSets               []*ReplicaSet             `json:"replicaSets"`
-	Roles                     []*map[string]interface{} `json:"roles"`
+	Roles                     []*CustomRole             `json:"roles"`
 	Sharding                  []*ShardingConfig         `json:"sharding"`
 	SSL                       *SSL                      `json:"ssl,omitempty"` // Deprecated: prefer TLS
 	TLS                       *SSL                      `json:"tls,omitempty"`
@@ -170,6 +170,31 @@ type CollationOptions struct {
 	Backwards       bool   `json:"backwards,omitempty"`
 }
 
+// LDAP configures LDAP authentication and authorization of the deployment.
+//
+// See: https://docs.opsmanager.mongodb.com/current/reference/api/automation-config/automation-config-parameters/#ldap
+type LDAP struct {
+	AuthzQueryTemplate            string `json:"authzQueryTemplate,omitempty"`            // AuthzQueryTemplate is the LDAP query to retrieve the groups of a user, for LDAP authorization
+	BindMethod                    string `json:"bindMethod,omitempty"`                    // BindMethod is simple or sasl
+	BindQueryPassword             string `json:"bindQueryPassword,omitempty"`             // BindQueryPassword is the password of BindQueryUser
+	BindQueryUser                 string `json:"bindQueryUser,omitempty"`                 // BindQueryUser is the user mongod binds with to run queries
+	BindSaslMechanisms            string `json:"bindSaslMechanisms,omitempty"`            // BindSaslMechanisms used when BindMethod is sasl
+	CAFileContents                string `json:"CAFileContents,omitempty"`                //nolint:tagliatelle // correct from API
+	Servers                       string `json:"servers,omitempty"`                       // Servers is a comma separated list of LDAP servers as host:port
+	TimeoutMS                     int    `json:"timeoutMS,omitempty"`                     //nolint:tagliatelle // correct from API
+	TransportSecurity             string `json:"transportSecurity,omitempty"`             // TransportSecurity is tls or none
+	UserCacheInvalidationInterval int    `json:"userCacheInvalidationInterval,omitempty"` // UserCacheInvalidationInterval in seconds
+	UserToDNMapping               string `json:"userToDNMapping,omitempty"`               // UserToDNMapping maps usernames to LDAP distinguished names
+	ValidateLDAPServerConfig      *bool  `json:"validateLDAPServerConfig,omitempty"`      //nolint:tagliatelle // correct from API
+}
+
+// Kerberos configures Kerberos authentication of the deployment.
+//
+// See: https://docs.opsmanager.mongodb.com/current/reference/api/automation-config/automation-config-parameters/#kerberos
+type Kerberos struct {
+	ServiceName string `json:"serviceName"` // ServiceName is the Kerberos service name of the processes, usually mongodb
+}
+
 // SSL config properties.
 //
 // See: https://docs.opsmanager.mongodb.com/current/reference/api/automation-config/automation-config-parameters/#tls
@@ -250,6 +275,31 @@ type Role struct {
 	Database string `json:"db"` //nolint:tagliatelle // Database is a better name than just db
 }
 
+// CustomRole is a user-defined database role.
+//
+// See: https://docs.opsmanager.mongodb.com/current/reference/api/automation-config/automation-config-parameters/#roles
+type CustomRole struct {
+	Role                       string                      `json:"role"`
+	Database                   string                      `json:"db"` //nolint:tagliatelle // Database is a better name than just db
+	Privileges                 []*Privilege                `json:"privileges"`
+	Roles                      []*Role                     `json:"roles"` // Roles the custom role inherits privileges from
+	AuthenticationRestrictions []AuthenticationRestriction `json:"authenticationRestrictions,omitempty"`
+}
+
+// Privilege is a set of actions allowed on a resource.
+type Privilege struct {
+	Resource Resource `json:"resource"`
+	Actions  []string `json:"actions"`
+}
+
+// Resource of a privilege, either a database and collection or the cluster.
+// An empty Database or Collection matches every database or collection.
+type Resource struct {
+	Database   *string `json:"db,omitempty"` //nolint:tagliatelle // Database is a better name than just db
+	Collection *string `json:"collection,omitempty"`
+	Cluster    *bool   `json:"cluster,omitempty"`
+}
+
 // ScramShaCreds configuration.
 type ScramShaCreds struct {
 	IterationCount int    `json:"iterationCount"`
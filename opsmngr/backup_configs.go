@@ -84,8 +84,15 @@ func (s *BackupConfigsServiceOp) List(ctx context.Context, groupID string, opts
 
 	root := new(BackupConfigs)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Get retrieves a single backup configuration by cluster ID.
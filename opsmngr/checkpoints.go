@@ -88,8 +88,15 @@ func (s *CheckpointsServiceOp) List(ctx context.Context, groupID, clusterName st
 
 	root := new(Checkpoints)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Get gets a checkpoint.
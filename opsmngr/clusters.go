@@ -55,8 +55,15 @@ func (s *ClustersServiceOp) List(ctx context.Context, groupID string, listOption
 
 	root := new(Clusters)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Get get a single cluster by ID.
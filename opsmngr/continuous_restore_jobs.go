@@ -114,8 +114,15 @@ func (s *ContinuousRestoreJobsServiceOp) List(ctx context.Context, groupID, clus
 
 	root := new(ContinuousJobs)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Get gets a continuous backup job in Ops Manager.
@@ -119,8 +119,15 @@ func (s *ContinuousSnapshotsServiceOp) List(ctx context.Context, groupID, cluste
 
 	root := new(ContinuousSnapshots)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Get gets the continuous snapshot for the given cluster and snapshot ID
@@ -98,8 +98,15 @@ func (s *DaemonConfigServiceOp) List(ctx context.Context, options *ListOptions)
 
 	root := new(Daemons)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Update updates a Daemon.
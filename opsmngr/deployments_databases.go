@@ -60,8 +60,15 @@ func (s *DeploymentsServiceOp) ListDatabases(ctx context.Context, groupID, hostI
 
 	root := new(ProcessDatabasesResponse)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // GetDatabase retrieve a single database by name.
@@ -60,8 +60,15 @@ func (s *DeploymentsServiceOp) ListPartitions(ctx context.Context, groupID, host
 
 	root := new(ProcessDisksResponse)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // GetPartition retrieves a disk partition.
@@ -93,8 +93,15 @@ func (s *DeploymentsServiceOp) ListHosts(ctx context.Context, groupID string, op
 
 	root := new(Hosts)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // GetHost gets the MongoDB process with the specified host ID.
@@ -0,0 +1,336 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"bytes"
+	"crypto/md5" // #nosec G501 // MD5 is the default algorithm of digest access authentication
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	digestMD5        = "MD5"
+	digestSHA256     = "SHA-256"
+	digestSessSuffix = "-sess"
+	digestQOPAuth    = "auth"
+	cnonceLength     = 16
+)
+
+// ErrUnsupportedDigestChallenge means the server didn't send a digest challenge this transport can answer.
+var ErrUnsupportedDigestChallenge = errors.New("unsupported digest challenge")
+
+// DigestTransport is an http.RoundTripper that authenticates requests using
+// HTTP Digest Access Authentication as defined by RFC 7616.
+// The challenge of the server is cached and reused across requests, so only the first request,
+// or a request whose nonce went stale, needs an extra round trip.
+type DigestTransport struct {
+	Username string
+	Password string
+	// Transport is the underlying http.RoundTripper, http.DefaultTransport is used if nil.
+	Transport http.RoundTripper
+
+	mu        sync.Mutex
+	challenge *digestChallenge
+	nc        uint32
+}
+
+var _ http.RoundTripper = &DigestTransport{}
+
+// NewDigestTransport returns a DigestTransport for the given username and password,
+// for Ops Manager these are the public and private keys of a programmatic API key.
+func NewDigestTransport(username, password string) *DigestTransport {
+	return &DigestTransport{
+		Username: username,
+		Password: password,
+	}
+}
+
+// Client returns an *http.Client that uses the DigestTransport.
+func (t *DigestTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// SetDigestAuth is a client option for authenticating requests with a programmatic API key.
+// The client must have been created with a nil httpClient or an *http.Client, whose Transport will be wrapped.
+func SetDigestAuth(publicKey, privateKey string) ClientOpt {
+	return func(c *Client) error {
+		hc, ok := c.client.(*http.Client)
+		if !ok {
+			return NewArgError("httpClient", "must be an *http.Client to use digest authentication")
+		}
+
+		t := NewDigestTransport(publicKey, privateKey)
+		t.Transport = hc.Transport
+		// copy the client so http.DefaultClient, or a client shared with other code, isn't modified
+		authClient := *hc
+		authClient.Transport = t
+		c.client = &authClient
+		return nil
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *DigestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.GetBody == nil {
+		// the body must be sent again if the cached challenge is rejected
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	// reuse the cached challenge, if any, to avoid an extra round trip
+	authorization, err := t.authorize(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.send(req, authorization)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// either there was no cached challenge or its nonce is no longer valid
+	return t.answer(req, resp)
+}
+
+func (t *DigestTransport) answer(req *http.Request, resp *http.Response) (*http.Response, error) {
+	c, err := parseDigestChallenges(resp.Header.Values("WWW-Authenticate"))
+	if err != nil {
+		// not a digest challenge, let the caller handle the 401
+		return resp, nil //nolint:nilerr // the original response is more useful than the parsing error
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBodyLength))
+	resp.Body.Close()
+
+	t.mu.Lock()
+	t.challenge = c
+	t.nc = 0
+	t.mu.Unlock()
+
+	authorization, err := t.authorize(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		req = req.Clone(req.Context())
+		if req.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return t.send(req, authorization)
+}
+
+func (t *DigestTransport) send(req *http.Request, authorization string) (*http.Response, error) {
+	if authorization != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", authorization)
+	}
+	return t.transport().RoundTrip(req)
+}
+
+func (t *DigestTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+// authorize returns the Authorization header for req using the cached challenge, if any.
+func (t *DigestTransport) authorize(req *http.Request) (string, error) {
+	t.mu.Lock()
+	c := t.challenge
+	if c == nil {
+		t.mu.Unlock()
+		return "", nil
+	}
+	t.nc++
+	nc := t.nc
+	t.mu.Unlock()
+
+	cnonce, err := newCnonce()
+	if err != nil {
+		return "", err
+	}
+	return c.authorization(t.Username, t.Password, req.Method, req.URL.RequestURI(), cnonce, nc), nil
+}
+
+func newCnonce() (string, error) {
+	b := make([]byte, cnonceLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// digestChallenge is a WWW-Authenticate digest challenge.
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+	userhash  bool
+}
+
+func (c *digestChallenge) hash() hash.Hash {
+	if strings.HasPrefix(strings.ToUpper(c.algorithm), digestSHA256) {
+		return sha256.New()
+	}
+	return md5.New() // #nosec G401 // MD5 is the default algorithm of digest access authentication
+}
+
+func (c *digestChallenge) h(s string) string {
+	h := c.hash()
+	_, _ = io.WriteString(h, s)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *digestChallenge) authorization(username, password, method, uri, cnonce string, nc uint32) string {
+	ha1 := c.h(username + ":" + c.realm + ":" + password)
+	if strings.HasSuffix(strings.ToLower(c.algorithm), digestSessSuffix) {
+		ha1 = c.h(ha1 + ":" + c.nonce + ":" + cnonce)
+	}
+	ha2 := c.h(method + ":" + uri)
+
+	if c.userhash {
+		username = c.h(username + ":" + c.realm)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `Digest username=%q, realm=%q, nonce=%q, uri=%q`, username, c.realm, c.nonce, uri)
+	if c.qop == "" {
+		fmt.Fprintf(&b, `, response=%q`, c.h(ha1+":"+c.nonce+":"+ha2))
+	} else {
+		ncValue := fmt.Sprintf("%08x", nc)
+		response := c.h(strings.Join([]string{ha1, c.nonce, ncValue, cnonce, c.qop, ha2}, ":"))
+		fmt.Fprintf(&b, `, qop=%s, nc=%s, cnonce=%q, response=%q`, c.qop, ncValue, cnonce, response)
+	}
+	if c.algorithm != "" {
+		fmt.Fprintf(&b, `, algorithm=%s`, c.algorithm)
+	}
+	if c.opaque != "" {
+		fmt.Fprintf(&b, `, opaque=%q`, c.opaque)
+	}
+	if c.userhash {
+		b.WriteString(`, userhash=true`)
+	}
+	return b.String()
+}
+
+// parseDigestChallenges returns the strongest supported digest challenge among the WWW-Authenticate headers.
+func parseDigestChallenges(headers []string) (*digestChallenge, error) {
+	var best *digestChallenge
+	for _, header := range headers {
+		scheme, params, found := strings.Cut(strings.TrimSpace(header), " ")
+		if !found || !strings.EqualFold(scheme, "Digest") {
+			continue
+		}
+		c, err := parseDigestChallenge(params)
+		if err != nil {
+			continue
+		}
+		if best == nil || (c.hash().Size() > best.hash().Size()) {
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, ErrUnsupportedDigestChallenge
+	}
+	return best, nil
+}
+
+func parseDigestChallenge(s string) (*digestChallenge, error) {
+	params := parseAuthParams(s)
+	c := &digestChallenge{
+		realm:     params["realm"],
+		nonce:     params["nonce"],
+		opaque:    params["opaque"],
+		algorithm: params["algorithm"],
+		userhash:  strings.EqualFold(params["userhash"], "true"),
+	}
+	if c.nonce == "" {
+		return nil, fmt.Errorf("%w: missing nonce", ErrUnsupportedDigestChallenge)
+	}
+
+	switch strings.ToUpper(strings.TrimSuffix(strings.ToLower(c.algorithm), digestSessSuffix)) {
+	case "", digestMD5, digestSHA256:
+	default:
+		return nil, fmt.Errorf("%w: algorithm %s", ErrUnsupportedDigestChallenge, c.algorithm)
+	}
+
+	if qop, ok := params["qop"]; ok {
+		for _, v := range strings.Split(qop, ",") {
+			if strings.TrimSpace(v) == digestQOPAuth {
+				c.qop = digestQOPAuth
+			}
+		}
+		if c.qop == "" {
+			return nil, fmt.Errorf("%w: qop %s", ErrUnsupportedDigestChallenge, qop)
+		}
+	}
+	return c, nil
+}
+
+// parseAuthParams parses a comma separated list of auth-param, values may be quoted strings.
+func parseAuthParams(s string) map[string]string {
+	params := map[string]string{}
+	for s != "" {
+		s = strings.TrimLeft(s, " \t,")
+		name, rest, found := strings.Cut(s, "=")
+		if !found {
+			break
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		rest = strings.TrimLeft(rest, " \t")
+
+		var value strings.Builder
+		if strings.HasPrefix(rest, `"`) {
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				value.WriteByte(rest[i])
+			}
+			if i < len(rest) {
+				i++ // closing quote
+			}
+			s = rest[i:]
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				end = len(rest)
+			}
+			value.WriteString(strings.TrimSpace(rest[:end]))
+			s = rest[end:]
+		}
+		params[name] = value.String()
+	}
+	return params
+}
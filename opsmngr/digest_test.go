@@ -0,0 +1,198 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"crypto/md5" // #nosec G501 // MD5 is the default algorithm of digest access authentication
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+const (
+	testPublicKey  = "public"
+	testPrivateKey = "private-key"
+	testRealm      = "MMS Public API"
+)
+
+// digestServer is a test server requiring digest authentication.
+type digestServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	algorithm  string
+	nonce      string
+	challenges int
+	requests   int
+}
+
+func newDigestServer(t *testing.T, algorithm string) *digestServer {
+	t.Helper()
+	s := &digestServer{algorithm: algorithm, nonce: "nonce0"}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests++
+
+		params := parseAuthParams(strings.TrimPrefix(r.Header.Get("Authorization"), "Digest "))
+		if params["nonce"] != s.nonce || params["response"] != s.expectedResponse(r, params) {
+			s.challenges++
+			stale := ""
+			if params["nonce"] != "" && params["nonce"] != s.nonce {
+				stale = ", stale=true"
+			}
+			w.Header().Add("WWW-Authenticate", `Basic realm="ignored"`)
+			w.Header().Add("WWW-Authenticate", fmt.Sprintf(`Digest realm=%q, qop="auth", nonce=%q, algorithm=%s, opaque="opaque"%s`, testRealm, s.nonce, s.algorithm, stale))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	return s
+}
+
+func (s *digestServer) expectedResponse(r *http.Request, params map[string]string) string {
+	var f func() hash.Hash = md5.New
+	if strings.HasPrefix(s.algorithm, "SHA-256") {
+		f = sha256.New
+	}
+	h := func(v string) string {
+		hh := f()
+		_, _ = io.WriteString(hh, v)
+		return hex.EncodeToString(hh.Sum(nil))
+	}
+
+	ha1 := h(testPublicKey + ":" + testRealm + ":" + testPrivateKey)
+	if strings.HasSuffix(s.algorithm, "-sess") {
+		ha1 = h(ha1 + ":" + s.nonce + ":" + params["cnonce"])
+	}
+	ha2 := h(r.Method + ":" + r.URL.RequestURI())
+	return h(strings.Join([]string{ha1, s.nonce, params["nc"], params["cnonce"], params["qop"], ha2}, ":"))
+}
+
+func TestDigestTransport(t *testing.T) {
+	for _, algorithm := range []string{"MD5", "SHA-256", "SHA-256-sess"} {
+		t.Run(algorithm, func(t *testing.T) {
+			server := newDigestServer(t, algorithm)
+			defer server.Close()
+
+			client, err := New(nil, SetBaseURL(server.URL), SetDigestAuth(testPublicKey, testPrivateKey))
+			if err != nil {
+				t.Fatalf("New returned error: %v", err)
+			}
+
+			for i := 0; i < 3; i++ {
+				req, _ := client.NewRequest(ctx, http.MethodPost, "foo?bar=1", map[string]int{"i": i})
+				body := new(map[string]int)
+				if _, err = client.Do(ctx, req, body); err != nil {
+					t.Fatalf("Do(): %v", err)
+				}
+				if diff := deep.Equal(*body, map[string]int{"i": i}); diff != nil {
+					t.Error(diff)
+				}
+			}
+
+			if server.challenges != 1 || server.requests != 4 {
+				t.Errorf("got %d challenges for %d requests, expected the nonce to be reused", server.challenges, server.requests)
+			}
+		})
+	}
+}
+
+func TestDigestTransport_staleNonce(t *testing.T) {
+	server := newDigestServer(t, "MD5")
+	defer server.Close()
+
+	client := NewDigestTransport(testPublicKey, testPrivateKey).Client()
+	for _, nonce := range []string{"nonce0", "nonce1"} {
+		server.mu.Lock()
+		server.nonce = nonce
+		server.mu.Unlock()
+
+		resp, err := client.Post(server.URL, jsonMediaType, strings.NewReader("body"))
+		if err != nil {
+			t.Fatalf("Post returned error: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != "body" {
+			t.Errorf("got %d %q, expected the body to be sent again with the new nonce", resp.StatusCode, body)
+		}
+	}
+
+	if server.challenges != 2 {
+		t.Errorf("got %d challenges, expected %d", server.challenges, 2)
+	}
+}
+
+func TestDigestTransport_invalidCredentials(t *testing.T) {
+	server := newDigestServer(t, "MD5")
+	defer server.Close()
+
+	client := NewDigestTransport(testPublicKey, "wrong").Client()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("StatusCode = %d, expected %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestSetDigestAuth(t *testing.T) {
+	if _, err := New(nil, SetDigestAuth(testPublicKey, testPrivateKey)); err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if http.DefaultClient.Transport != nil {
+		t.Error("http.DefaultClient must not be modified")
+	}
+
+	type customClient struct{ HTTPClient }
+	if _, err := New(customClient{}, SetDigestAuth(testPublicKey, testPrivateKey)); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestParseDigestChallenges(t *testing.T) {
+	c, err := parseDigestChallenges([]string{
+		`Digest realm="a", nonce="n1", qop="auth,auth-int", algorithm=MD5`,
+		`Digest realm="a \"quoted\"", nonce="n2", qop="auth", algorithm=SHA-256, userhash=true`,
+	})
+	if err != nil {
+		t.Fatalf("parseDigestChallenges returned error: %v", err)
+	}
+	expected := &digestChallenge{realm: `a "quoted"`, nonce: "n2", algorithm: "SHA-256", qop: "auth", userhash: true}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("parseDigestChallenges() = %+v, expected %+v", c, expected)
+	}
+
+	for _, header := range []string{`Basic realm="a"`, `Digest realm="a"`, `Digest nonce="n", algorithm=SHA-512-256`, `Digest nonce="n", qop="auth-int"`} {
+		if _, err := parseDigestChallenges([]string{header}); err == nil {
+			t.Errorf("expected an error for %s", header)
+		}
+	}
+}
@@ -35,33 +35,31 @@ can be used as a starting point.
 
 # Authentication
 
-The ops-manager library does not directly handle authentication. Instead, when
-creating a new client, pass an http.Client that can handle Digest Access authentication for
-you. The easiest way to do this is using the https://github.com/mongodb-forks/digest
-library, but you can always use any other library that provides an `http.Client`.
-If you have a private and public API token pair, you can use it with the digest library using:
+If you have a private and public API key pair, use the SetDigestAuth client option
+to authenticate every request using Digest Access authentication:
 
 	import (
 		"context"
 		"log"
 
-		"github.com/mongodb-forks/digest"
 		"go.mongodb.org/ops-manager/opsmngr"
 	)
 
 	func main() {
-		t := digest.NewTransport("your public key", "your private key")
-		tc, err := t.Client()
+		// Note: If no Base URL is set the client is set to work with Cloud Manager by default
+		client, err := opsmngr.New(nil,
+			opsmngr.SetBaseURL("https://opsmanagerurl/"),
+			opsmngr.SetDigestAuth("your public key", "your private key"),
+		)
 		if err != nil {
 			log.Fatalf(err.Error())
 		}
-
-		// Note: If no Base URL is set the client is set to work with Cloud Manager by default
-		clientops := opsmngr.SetBaseURL("https://opsmanagerurl/" + opsmngr.APIPublicV1Path)
-		client, err := opsmngr.New(tc, clientops)
 		orgs, _, err := client.Organizations.List(context.Background(), nil)
 	}
 
+You can also pass your own http.Client when creating a new client, for example one built with
+NewDigestTransport or with any other library that can handle the authentication for you.
+
 Note that when using an authenticated Client, all calls made by the client will
 include the specified tokens. Therefore, authenticated clients should
 almost never be shared between different users.
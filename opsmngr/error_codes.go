@@ -0,0 +1,103 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gen_error_codes.go; DO NOT EDIT.
+
+package opsmngr
+
+// Known error codes returned by Ops Manager.
+const (
+	// ErrorCodeAlertConfigNotFound means no alert configuration with the given ID exists.
+	ErrorCodeAlertConfigNotFound ErrorCode = "ALERT_CONFIG_NOT_FOUND"
+	// ErrorCodeAlertNotFound means no alert with the given ID exists.
+	ErrorCodeAlertNotFound ErrorCode = "ALERT_NOT_FOUND"
+	// ErrorCodeAPIKeyNotFound means no API key with the given ID exists.
+	ErrorCodeAPIKeyNotFound ErrorCode = "API_KEY_NOT_FOUND"
+	// ErrorCodeCannotDeleteOrgActiveGroups means the organization still has projects.
+	ErrorCodeCannotDeleteOrgActiveGroups ErrorCode = "CANNOT_DELETE_ORG_ACTIVE_GROUPS"
+	// ErrorCodeCannotDeleteSnapshot means the snapshot is marked as do not delete.
+	ErrorCodeCannotDeleteSnapshot ErrorCode = "CANNOT_DELETE_SNAPSHOT"
+	// ErrorCodeConflictingAutomationConfig means the automation config was updated since it was read.
+	ErrorCodeConflictingAutomationConfig ErrorCode = "CONFLICTING_AUTOMATION_CONFIG"
+	// ErrorCodeDeviceAuthorizationExpired means the device authorization expired before the user approved it.
+	ErrorCodeDeviceAuthorizationExpired ErrorCode = "DEVICE_AUTHORIZATION_EXPIRED"
+	// ErrorCodeDeviceAuthorizationPending means the user didn't approve the device authorization yet.
+	ErrorCodeDeviceAuthorizationPending ErrorCode = "DEVICE_AUTHORIZATION_PENDING"
+	// ErrorCodeDuplicateGroupName means a project with the given name already exists.
+	ErrorCodeDuplicateGroupName ErrorCode = "DUPLICATE_GROUP_NAME"
+	// ErrorCodeGroupNameNotFound means no project with the given name exists.
+	ErrorCodeGroupNameNotFound ErrorCode = "GROUP_NAME_NOT_FOUND"
+	// ErrorCodeGroupNotFound means no project with the given ID exists.
+	ErrorCodeGroupNotFound ErrorCode = "GROUP_NOT_FOUND"
+	// ErrorCodeHostAlreadyExists means the host is already monitored.
+	ErrorCodeHostAlreadyExists ErrorCode = "HOST_ALREADY_EXISTS"
+	// ErrorCodeHostNotFound means no host with the given ID or name exists.
+	ErrorCodeHostNotFound ErrorCode = "HOST_NOT_FOUND"
+	// ErrorCodeInvalidAttribute means an attribute of the request is invalid.
+	ErrorCodeInvalidAttribute ErrorCode = "INVALID_ATTRIBUTE"
+	// ErrorCodeInvalidJSON means the request body is not valid JSON.
+	ErrorCodeInvalidJSON ErrorCode = "INVALID_JSON"
+	// ErrorCodeMethodNotAllowed means the resource doesn't support the method of the request.
+	ErrorCodeMethodNotAllowed ErrorCode = "METHOD_NOT_ALLOWED"
+	// ErrorCodeMissingAttribute means a required attribute of the request is missing.
+	ErrorCodeMissingAttribute ErrorCode = "MISSING_ATTRIBUTE"
+	// ErrorCodeNotInGroup means the resource doesn't belong to the project.
+	ErrorCodeNotInGroup ErrorCode = "NOT_IN_GROUP"
+	// ErrorCodeOrgNotFound means no organization with the given ID exists.
+	ErrorCodeOrgNotFound ErrorCode = "ORG_NOT_FOUND"
+	// ErrorCodeRateLimited means too many requests were sent, retry later.
+	ErrorCodeRateLimited ErrorCode = "RATE_LIMITED"
+	// ErrorCodeResourceNotFound means the requested resource doesn't exist.
+	ErrorCodeResourceNotFound ErrorCode = "RESOURCE_NOT_FOUND"
+	// ErrorCodeSnapshotNotFound means no snapshot with the given ID exists.
+	ErrorCodeSnapshotNotFound ErrorCode = "SNAPSHOT_NOT_FOUND"
+	// ErrorCodeUnexpectedError means an unexpected error happened on the server.
+	ErrorCodeUnexpectedError ErrorCode = "UNEXPECTED_ERROR"
+	// ErrorCodeUsernameNotFound means no user with the given username exists.
+	ErrorCodeUsernameNotFound ErrorCode = "USERNAME_NOT_FOUND"
+	// ErrorCodeUserNotFound means no user with the given ID exists.
+	ErrorCodeUserNotFound ErrorCode = "USER_NOT_FOUND"
+	// ErrorCodeUserUnauthorized means the user isn't authorized to perform the request.
+	ErrorCodeUserUnauthorized ErrorCode = "USER_UNAUTHORIZED"
+)
+
+// Sentinel errors of the known error codes, errors.Is matches an *ErrorResponse with the same ErrorCode.
+var (
+	ErrAlertConfigNotFound         = &ErrorResponse{ErrorCode: string(ErrorCodeAlertConfigNotFound)}
+	ErrAlertNotFound               = &ErrorResponse{ErrorCode: string(ErrorCodeAlertNotFound)}
+	ErrAPIKeyNotFound              = &ErrorResponse{ErrorCode: string(ErrorCodeAPIKeyNotFound)}
+	ErrCannotDeleteOrgActiveGroups = &ErrorResponse{ErrorCode: string(ErrorCodeCannotDeleteOrgActiveGroups)}
+	ErrCannotDeleteSnapshot        = &ErrorResponse{ErrorCode: string(ErrorCodeCannotDeleteSnapshot)}
+	ErrConflictingAutomationConfig = &ErrorResponse{ErrorCode: string(ErrorCodeConflictingAutomationConfig)}
+	ErrDeviceAuthorizationExpired  = &ErrorResponse{ErrorCode: string(ErrorCodeDeviceAuthorizationExpired)}
+	ErrDeviceAuthorizationPending  = &ErrorResponse{ErrorCode: string(ErrorCodeDeviceAuthorizationPending)}
+	ErrDuplicateGroupName          = &ErrorResponse{ErrorCode: string(ErrorCodeDuplicateGroupName)}
+	ErrGroupNameNotFound           = &ErrorResponse{ErrorCode: string(ErrorCodeGroupNameNotFound)}
+	ErrGroupNotFound               = &ErrorResponse{ErrorCode: string(ErrorCodeGroupNotFound)}
+	ErrHostAlreadyExists           = &ErrorResponse{ErrorCode: string(ErrorCodeHostAlreadyExists)}
+	ErrHostNotFound                = &ErrorResponse{ErrorCode: string(ErrorCodeHostNotFound)}
+	ErrInvalidAttribute            = &ErrorResponse{ErrorCode: string(ErrorCodeInvalidAttribute)}
+	ErrInvalidJSON                 = &ErrorResponse{ErrorCode: string(ErrorCodeInvalidJSON)}
+	ErrMethodNotAllowed            = &ErrorResponse{ErrorCode: string(ErrorCodeMethodNotAllowed)}
+	ErrMissingAttribute            = &ErrorResponse{ErrorCode: string(ErrorCodeMissingAttribute)}
+	ErrNotInGroup                  = &ErrorResponse{ErrorCode: string(ErrorCodeNotInGroup)}
+	ErrOrgNotFound                 = &ErrorResponse{ErrorCode: string(ErrorCodeOrgNotFound)}
+	ErrRateLimited                 = &ErrorResponse{ErrorCode: string(ErrorCodeRateLimited)}
+	ErrResourceNotFound            = &ErrorResponse{ErrorCode: string(ErrorCodeResourceNotFound)}
+	ErrSnapshotNotFound            = &ErrorResponse{ErrorCode: string(ErrorCodeSnapshotNotFound)}
+	ErrUnexpectedError             = &ErrorResponse{ErrorCode: string(ErrorCodeUnexpectedError)}
+	ErrUsernameNotFound            = &ErrorResponse{ErrorCode: string(ErrorCodeUsernameNotFound)}
+	ErrUserNotFound                = &ErrorResponse{ErrorCode: string(ErrorCodeUserNotFound)}
+	ErrUserUnauthorized            = &ErrorResponse{ErrorCode: string(ErrorCodeUserUnauthorized)}
+)
@@ -14,7 +14,16 @@
 
 package opsmngr
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+//go:generate go run gen_error_codes.go
+
+// ErrorCode identifies the error returned by an API request, see error_codes.go for the known ones.
+type ErrorCode string
 
 // ArgError is an error that represents an error with an input to godo. It
 // identifies the argument and the cause (if possible).
@@ -36,3 +45,24 @@ func NewArgError(arg, reason string) *ArgError {
 func (e *ArgError) Error() string {
 	return fmt.Sprintf("%s is invalid because %s", e.arg, e.reason)
 }
+
+// IsNotFound reports whether err is an *ErrorResponse for a resource that doesn't exist.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an *ErrorResponse for a request conflicting with the current state,
+// like an automation config update based on an outdated version.
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err is an *ErrorResponse for a request rejected by the rate limiting of Ops Manager.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests) || errors.Is(err, ErrRateLimited)
+}
+
+func hasStatusCode(err error, code int) bool {
+	var target *ErrorResponse
+	return errors.As(err, &target) && target.statusCode() == code
+}
@@ -113,6 +113,10 @@ func (s *EventsServiceOp) ListOrganizationEvents(ctx context.Context, orgID stri
 		return nil, resp, err
 	}
 
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
 	return root, resp, nil
 }
 
@@ -169,6 +173,10 @@ func (s *EventsServiceOp) ListProjectEvents(ctx context.Context, groupID string,
 		return nil, resp, err
 	}
 
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
 	return root, resp, nil
 }
 
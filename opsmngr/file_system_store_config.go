@@ -89,8 +89,15 @@ func (s *FileSystemStoreConfigServiceOp) List(ctx context.Context, opts *ListOpt
 
 	root := new(FileSystemStoreConfigurations)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Create configures one new file system store.
@@ -0,0 +1,140 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+
+// gen_error_codes generates error_codes.go, the constants and sentinel errors
+// of the known Ops Manager error codes. Add new codes to errorCodes and run go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// errorCodes maps the known error codes to the description of the error.
+var errorCodes = map[string]string{
+	"ALERT_CONFIG_NOT_FOUND":          "no alert configuration with the given ID exists",
+	"ALERT_NOT_FOUND":                 "no alert with the given ID exists",
+	"API_KEY_NOT_FOUND":               "no API key with the given ID exists",
+	"CANNOT_DELETE_ORG_ACTIVE_GROUPS": "the organization still has projects",
+	"CANNOT_DELETE_SNAPSHOT":          "the snapshot is marked as do not delete",
+	"CONFLICTING_AUTOMATION_CONFIG":   "the automation config was updated since it was read",
+	"DEVICE_AUTHORIZATION_EXPIRED":    "the device authorization expired before the user approved it",
+	"DEVICE_AUTHORIZATION_PENDING":    "the user didn't approve the device authorization yet",
+	"DUPLICATE_GROUP_NAME":            "a project with the given name already exists",
+	"GROUP_NAME_NOT_FOUND":            "no project with the given name exists",
+	"GROUP_NOT_FOUND":                 "no project with the given ID exists",
+	"HOST_ALREADY_EXISTS":             "the host is already monitored",
+	"HOST_NOT_FOUND":                  "no host with the given ID or name exists",
+	"INVALID_ATTRIBUTE":               "an attribute of the request is invalid",
+	"INVALID_JSON":                    "the request body is not valid JSON",
+	"METHOD_NOT_ALLOWED":              "the resource doesn't support the method of the request",
+	"MISSING_ATTRIBUTE":               "a required attribute of the request is missing",
+	"NOT_IN_GROUP":                    "the resource doesn't belong to the project",
+	"ORG_NOT_FOUND":                   "no organization with the given ID exists",
+	"RATE_LIMITED":                    "too many requests were sent, retry later",
+	"RESOURCE_NOT_FOUND":              "the requested resource doesn't exist",
+	"SNAPSHOT_NOT_FOUND":              "no snapshot with the given ID exists",
+	"UNEXPECTED_ERROR":                "an unexpected error happened on the server",
+	"USER_NOT_FOUND":                  "no user with the given ID exists",
+	"USER_UNAUTHORIZED":               "the user isn't authorized to perform the request",
+	"USERNAME_NOT_FOUND":              "no user with the given username exists",
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gen_error_codes.go; DO NOT EDIT.
+
+package opsmngr
+
+// Known error codes returned by Ops Manager.
+const (
+{{- range .}}
+	// ErrorCode{{.Name}} means {{.Description}}.
+	ErrorCode{{.Name}} ErrorCode = "{{.Code}}"
+{{- end}}
+)
+
+// Sentinel errors of the known error codes, errors.Is matches an *ErrorResponse with the same ErrorCode.
+var (
+{{- range .}}
+	Err{{.Name}} = &ErrorResponse{ErrorCode: string(ErrorCode{{.Name}})}
+{{- end}}
+)
+`))
+
+type errorCode struct {
+	Code        string
+	Name        string
+	Description string
+}
+
+func main() {
+	codes := make([]errorCode, 0, len(errorCodes))
+	for code, description := range errorCodes {
+		codes = append(codes, errorCode{Code: code, Name: camelCase(code), Description: description})
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, codes); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	//nolint:gosec // generated source code is world readable
+	if err := os.WriteFile("error_codes.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// initialisms keep their case in the names of the constants.
+var initialisms = map[string]bool{"API": true, "ID": true, "JSON": true}
+
+// camelCase converts an error code like GROUP_NOT_FOUND to GroupNotFound.
+func camelCase(code string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(code, "_") {
+		if word == "" {
+			continue
+		}
+		if initialisms[word] {
+			b.WriteString(word)
+			continue
+		}
+		fmt.Fprintf(&b, "%s%s", word[:1], strings.ToLower(word[1:]))
+	}
+	return b.String()
+}
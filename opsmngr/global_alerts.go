@@ -89,8 +89,15 @@ func (s *GlobalAlertsServiceOp) List(ctx context.Context, opts *AlertsListOption
 
 	root := new(GlobalAlerts)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Acknowledge acknowledges a global alert.
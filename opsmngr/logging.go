@@ -0,0 +1,319 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxLoggedBodyLength = 4096
+	redacted                   = "REDACTED"
+	truncated                  = "...(truncated)"
+)
+
+// sensitiveHeaders are the HTTP headers holding credentials.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// LogOptions defines how SetLogger logs requests and responses.
+type LogOptions struct {
+	// RequestLevel is the level of the log of every request sent.
+	RequestLevel slog.Level
+	// ResponseLevel is the level of the log of every successful response.
+	ResponseLevel slog.Level
+	// ErrorLevel is the level of the log of error responses and of requests that failed without a response.
+	ErrorLevel slog.Level
+	// MaxBodyLength truncates the logged bodies, zero doesn't log bodies.
+	MaxBodyLength int
+	// RedactFields are JSON fields redacted in addition to the sensitive fields of this library.
+	RedactFields []string
+}
+
+// DefaultLogOptions returns LogOptions logging requests and responses at the debug level,
+// errors at the warn level and bodies up to 4096 bytes.
+func DefaultLogOptions() *LogOptions {
+	return &LogOptions{
+		RequestLevel:  slog.LevelDebug,
+		ResponseLevel: slog.LevelDebug,
+		ErrorLevel:    slog.LevelWarn,
+		MaxBodyLength: defaultMaxLoggedBodyLength,
+	}
+}
+
+// requestLogger logs the requests sent by a Client.
+type requestLogger struct {
+	logger *slog.Logger
+	opts   LogOptions
+	redact map[string]bool // RedactFields
+}
+
+// SetLogger is a client option for logging every request and response with logger, nil options use DefaultLogOptions.
+// The credentials in headers and the secrets in JSON bodies, like passwords of the automation config
+// or private keys of API keys, are redacted. Only JSON bodies are logged.
+func SetLogger(logger *slog.Logger, opts *LogOptions) ClientOpt {
+	return func(c *Client) error {
+		if logger == nil {
+			return NewArgError("logger", "must be set")
+		}
+		if opts == nil {
+			opts = DefaultLogOptions()
+		}
+		if opts.MaxBodyLength < 0 {
+			return NewArgError("MaxBodyLength", "must be a positive number")
+		}
+
+		redact := make(map[string]bool, len(opts.RedactFields))
+		for _, f := range opts.RedactFields {
+			redact[f] = true
+		}
+		c.logger = &requestLogger{logger: logger, opts: *opts, redact: redact}
+		return nil
+	}
+}
+
+// do sends the request with client, logging the request and its response.
+func (l *requestLogger) do(ctx context.Context, client HTTPClient, req *http.Request, attempt int) (*http.Response, error) {
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Int("attempt", attempt+1),
+	}
+	if l.logger.Enabled(ctx, l.opts.RequestLevel) {
+		reqAttrs := append(attrs[:len(attrs):len(attrs)], slog.Any("header", l.header(req.Header)))
+		if body := l.requestBody(req); body != "" {
+			reqAttrs = append(reqAttrs, slog.String("body", body))
+		}
+		l.logger.LogAttrs(ctx, l.opts.RequestLevel, "Ops Manager request", reqAttrs...)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+	if err != nil {
+		l.logger.LogAttrs(ctx, l.opts.ErrorLevel, "Ops Manager request failed", append(attrs, slog.String("error", err.Error()))...)
+		return resp, err
+	}
+
+	level := l.opts.ResponseLevel
+	if resp.StatusCode >= http.StatusBadRequest {
+		level = l.opts.ErrorLevel
+	}
+	if l.logger.Enabled(ctx, level) {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode), slog.Any("header", l.header(resp.Header)))
+		body, err := l.responseBody(resp)
+		if err != nil {
+			l.logger.LogAttrs(ctx, l.opts.ErrorLevel, "Ops Manager request failed", append(attrs, slog.String("error", err.Error()))...)
+			return nil, err
+		}
+		if body != "" {
+			attrs = append(attrs, slog.String("body", body))
+		}
+		l.logger.LogAttrs(ctx, level, "Ops Manager response", attrs...)
+	}
+	return resp, nil
+}
+
+func (l *requestLogger) header(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range sensitiveHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, redacted)
+		}
+	}
+	return h
+}
+
+// requestBody returns the redacted JSON body of the request, leaving it readable.
+func (l *requestLogger) requestBody(req *http.Request) string {
+	if l.opts.MaxBodyLength == 0 || req.GetBody == nil || !isJSON(req.Header) {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, int64(l.opts.MaxBodyLength)+1))
+	if err != nil {
+		return ""
+	}
+	return l.format(data)
+}
+
+// responseBody returns the redacted JSON body of the response, leaving it readable,
+// or the error that prevented reading it. Only the logged part of the body is read.
+func (l *requestLogger) responseBody(resp *http.Response) (string, error) {
+	if l.opts.MaxBodyLength == 0 || resp.Body == nil || !isJSON(resp.Header) {
+		return "", nil
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(l.opts.MaxBodyLength)+1))
+	if err != nil {
+		resp.Body.Close()
+		return "", err
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+	return l.format(data), nil
+}
+
+// logFrame is an object or array being written by format.
+type logFrame struct {
+	object bool
+	path   string // JSON path of the object or array, without array indexes
+	n      int    // elements written
+	key    string // key of the value being written, for objects
+	value  bool   // whether key has been written and its value is expected, for objects
+}
+
+// format redacts the sensitive fields of a JSON body and truncates it to MaxBodyLength.
+// The body is read one token at a time so the beginning of a body longer than MaxBodyLength
+// can be redacted and logged.
+func (l *requestLogger) format(data []byte) string {
+	partial := len(data) > l.opts.MaxBodyLength
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var b bytes.Buffer
+	var stack []*logFrame
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if !partial {
+				// an invalid body could hold secrets the redaction can't find
+				return redacted
+			}
+			break
+		}
+
+		var top *logFrame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		if top != nil && top.object && !top.value {
+			if delim, ok := tok.(json.Delim); ok && delim == '}' {
+				b.WriteByte('}')
+				stack = stack[:len(stack)-1]
+				if len(stack) == 0 {
+					break
+				}
+				continue
+			}
+			key, _ := tok.(string)
+			if top.n > 0 {
+				b.WriteByte(',')
+			}
+			top.n++
+			writeJSON(&b, key)
+			b.WriteByte(':')
+			if l.redact[key] || IsSensitivePath(joinPath(top.path, key)) {
+				skipValue(d)
+				writeJSON(&b, redacted)
+				continue
+			}
+			top.key, top.value = key, true
+			continue
+		}
+
+		if delim, ok := tok.(json.Delim); ok && delim == ']' {
+			b.WriteByte(']')
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				break
+			}
+			continue
+		}
+		path := ""
+		if top != nil {
+			path = top.path
+			if top.object {
+				path = joinPath(path, top.key)
+				top.value = false
+			} else {
+				if top.n > 0 {
+					b.WriteByte(',')
+				}
+				top.n++
+			}
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			b.WriteRune(rune(delim))
+			stack = append(stack, &logFrame{object: delim == '{', path: path})
+			continue
+		}
+		writeJSON(&b, tok)
+		if len(stack) == 0 {
+			break
+		}
+	}
+
+	if b.Len() > l.opts.MaxBodyLength {
+		return string(b.Bytes()[:l.opts.MaxBodyLength]) + truncated
+	}
+	if partial {
+		return b.String() + truncated
+	}
+	return b.String()
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func writeJSON(b *bytes.Buffer, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data = []byte(`"` + redacted + `"`)
+	}
+	b.Write(data)
+}
+
+// skipValue skips the next value of d, which may be an object or an array.
+func skipValue(d *json.Decoder) {
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			default:
+				depth--
+			}
+		}
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+func isJSON(h http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	return err == nil && mediaType == jsonMediaType
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// logRecords decodes the records written by a slog.JSONHandler.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		r := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid log record %s: %v", line, err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestSetLogger(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if err := SetLogger(logger, nil)(client); err != nil {
+		t.Fatalf("SetLogger returned error: %v", err)
+	}
+
+	mux.HandleFunc("/groups/1/automationConfig", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", jsonMediaType)
+		_, _ = fmt.Fprint(w, `{"auth": {"autoUser": "mms-automation", "autoPwd": "agentSecret", "key": "keyfileSecret"}, "version": 2, "sslPEMKeyPwd": "pemSecret"}`)
+	})
+
+	config := &AutomationConfig{
+		Auth: Auth{
+			AutoPwd:     "agentSecret",
+			UsersWanted: []*MongoDBUser{{Username: "user", Database: "admin", InitPassword: "userSecret"}},
+		},
+	}
+	req, _ := client.NewRequest(ctx, http.MethodPut, "groups/1/automationConfig", config)
+	req.Header.Set("Authorization", "Bearer tokenSecret")
+	got := new(AutomationConfig)
+	if _, err := client.Do(ctx, req, got); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if got.Auth.AutoPwd != "agentSecret" || got.Version != 2 {
+		t.Errorf("expected the response to be decoded after logging, got %+v", got)
+	}
+
+	for _, secret := range []string{"agentSecret", "keyfileSecret", "userSecret", "tokenSecret", "pemSecret"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("log contains secret %q: %s", secret, buf)
+		}
+	}
+	records := logRecords(t, buf)
+	if len(records) != 2 {
+		t.Fatalf("expected a request and a response record, got %d", len(records))
+	}
+	if records[0]["level"] != "DEBUG" || records[0]["method"] != http.MethodPut || !strings.Contains(records[0]["body"].(string), `"initPwd":"REDACTED"`) {
+		t.Errorf("unexpected request record %v", records[0])
+	}
+	if records[1]["status"] != float64(http.StatusOK) || !strings.Contains(records[1]["body"].(string), `"autoUser":"mms-automation"`) {
+		t.Errorf("unexpected response record %v", records[1])
+	}
+}
+
+func TestSetLogger_errorsAndTruncation(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	opts := DefaultLogOptions()
+	opts.MaxBodyLength = 20
+	opts.RedactFields = []string{"detail"}
+	if err := SetLogger(logger, opts)(client); err != nil {
+		t.Fatalf("SetLogger returned error: %v", err)
+	}
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", jsonMediaType)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{"detail": "detailSecret", "error": 404, "errorCode": "GROUP_NOT_FOUND", "reason": "Not Found"}`)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "groups/1", nil)
+	if _, err := client.Do(ctx, req, nil); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	records := logRecords(t, buf)
+	if len(records) != 1 || records[0]["level"] != "WARN" {
+		t.Fatalf("expected only the error response to be logged at the info level, got %v", records)
+	}
+	body := records[0]["body"].(string)
+	if strings.Contains(body, "detailSecret") || !strings.HasSuffix(body, truncated) || len(body) != opts.MaxBodyLength+len(truncated) {
+		t.Errorf("expected a redacted and truncated body, got %s", body)
+	}
+}
+
+func TestSetLogger_invalid(t *testing.T) {
+	if err := SetLogger(nil, nil)(NewClient(nil)); err == nil {
+		t.Error("expected an error without logger")
+	}
+	if err := SetLogger(slog.Default(), &LogOptions{MaxBodyLength: -1})(NewClient(nil)); err == nil {
+		t.Error("expected an error for a negative MaxBodyLength")
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+type httpClientFunc func(*http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestSetLogger_bodyReadError(t *testing.T) {
+	client := NewClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{jsonMediaType}},
+			Body:       io.NopCloser(io.MultiReader(strings.NewReader(`{"version": `), errReader{})),
+			Request:    req,
+		}, nil
+	}))
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if err := SetLogger(logger, nil)(client); err != nil {
+		t.Fatalf("SetLogger returned error: %v", err)
+	}
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "groups/1/automationConfig", nil)
+	if _, err := client.Do(ctx, req, new(AutomationConfig)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected the error reading the body, got %v", err)
+	}
+}
+
+func TestSetLogger_longBody(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	const prefix = `{"indexConfigs":[{"key":[["name","1"]]}],"auth":{"autoPwd":`
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	opts := DefaultLogOptions()
+	opts.MaxBodyLength = len(prefix + `"agentSecre`) // cuts the body within the password
+	if err := SetLogger(logger, opts)(client); err != nil {
+		t.Fatalf("SetLogger returned error: %v", err)
+	}
+
+	mux.HandleFunc("/groups/1/automationConfig", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", jsonMediaType)
+		_, _ = fmt.Fprint(w, prefix+`"agentSecret","key":"keyfileSecret"},"version":2}`)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "groups/1/automationConfig", nil)
+	got := new(AutomationConfig)
+	if _, err := client.Do(ctx, req, got); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if got.Auth.Key != "keyfileSecret" || got.Version != 2 {
+		t.Errorf("expected the whole response to be decoded after logging, got %+v", got)
+	}
+
+	records := logRecords(t, buf)
+	if len(records) != 2 {
+		t.Fatalf("expected a request and a response record, got %d", len(records))
+	}
+	if want := prefix + `"REDACTED"` + truncated; records[1]["body"] != want {
+		t.Errorf("expected the redacted beginning of the body %s, got %s", want, records[1]["body"])
+	}
+}
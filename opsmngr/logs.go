@@ -129,8 +129,15 @@ func (s *LogCollectionServiceOp) List(ctx context.Context, groupID string, opts
 
 	root := new(LogCollectionJobs)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Get gets a log collection job.
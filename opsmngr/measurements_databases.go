@@ -58,5 +58,9 @@ func (s *MeasurementsServiceOp) Database(ctx context.Context, groupID, hostID, d
 		return nil, resp, err
 	}
 
-	return root, resp, err
+	if root.ProcessMeasurements != nil && root.Links != nil {
+		resp.Links = root.Links
+	}
+
+	return root, resp, nil
 }
@@ -49,6 +49,13 @@ func (s *MeasurementsServiceOp) Host(ctx context.Context, projectID, hostID stri
 
 	root := new(ProcessMeasurements)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
 
-	return root, resp, err
+	return root, resp, nil
 }
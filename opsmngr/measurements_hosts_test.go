@@ -15,6 +15,7 @@
 package opsmngr
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -120,3 +121,34 @@ func TestMeasurements_Host(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestMeasurements_Host_pages(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	path := fmt.Sprintf("/api/public/v1.0/groups/%s/hosts/hostID/measurements", projectID)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		next := ""
+		if r.URL.Query().Get("pageNum") != "2" {
+			next = fmt.Sprintf(`{"href": "http://mms:8080%s?pageNum=2&granularity=PT1M", "rel": "next"}`, path)
+		}
+		_, _ = fmt.Fprintf(w, `{"links": [%s], "measurements": [{"name": "CONNECTIONS_%s", "units": "SCALAR"}]}`, next, r.URL.Query().Get("pageNum"))
+	})
+
+	fetch := func(ctx context.Context, opts *ListOptions) ([]*Measurements, *Response, error) {
+		measurements, resp, err := client.Measurements.Host(ctx, projectID, "hostID", &ProcessMeasurementListOptions{ListOptions: opts, Granularity: "PT1M"})
+		if err != nil {
+			return nil, resp, err
+		}
+		return measurements.Measurements, resp, nil
+	}
+	measurements, err := NewPager(fetch, &ListOptions{PageNum: 1}).All(ctx)
+	if err != nil {
+		t.Fatalf("Pager.All returned error: %v", err)
+	}
+	expected := []*Measurements{{Name: "CONNECTIONS_1", Units: "SCALAR"}, {Name: "CONNECTIONS_2", Units: "SCALAR"}}
+	if diff := deep.Equal(measurements, expected); diff != nil {
+		t.Error(diff)
+	}
+}
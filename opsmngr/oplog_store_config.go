@@ -74,8 +74,15 @@ func (s *OplogStoreConfigServiceOp) List(ctx context.Context, options *ListOptio
 
 	root := new(BackupStores)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Create create a Oplog.
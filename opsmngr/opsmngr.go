@@ -85,6 +85,15 @@ type Client struct {
 	// copy raw server response to the Response struct
 	withRaw bool
 
+	// retry failed idempotent requests, nil disables retries
+	retryPolicy *RetryPolicy
+
+	// limit the rate of requests, nil disables rate limiting
+	rateLimiter *RateLimiter
+
+	// log requests and responses, nil disables logging
+	logger *requestLogger
+
 	Organizations          OrganizationsService
 	Projects               ProjectsService
 	Users                  UsersService
@@ -202,10 +211,16 @@ func (resp *Response) CurrentPage() (int, error) {
 	return pageNum, nil
 }
 
+// IsLastPage returns true if the response has no "next" link relation.
+func (resp *Response) IsLastPage() bool {
+	return resp.getLinkByRef("next") == nil
+}
+
 // NewClient returns a new Ops Manager API client. If a nil httpClient is
 // provided, a http.DefaultClient will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
-// for you (such as that provided by the https://github.com/mongodb-forks/digest).
+// for you (such as the one returned by NewDigestTransport(publicKey, privateKey).Client()),
+// or use New with the SetDigestAuth option.
 func NewClient(httpClient HTTPClient) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
@@ -306,6 +321,7 @@ func SetBaseURL(bu string) ClientOpt {
 }
 
 // SetWithRaw is a client option for getting raw Ops Manager server response within Response structure.
+// The raw response isn't redacted, use SetLogger to debug requests without leaking secrets.
 func SetWithRaw() ClientOpt {
 	return func(c *Client) error {
 		c.withRaw = true
@@ -420,7 +436,7 @@ func (c *Client) OnResponseProcessed(rc ResponseProcessedCallback) {
 type ErrorResponse struct {
 	// Response that caused this error
 	Response *http.Response
-	// ErrorCode is the error code
+	// ErrorCode is the error code, see Code for comparing it with the known error codes
 	ErrorCode string `json:"errorCode"`
 	// HTTPCode status code.
 	HTTPCode int `json:"error"` //nolint:tagliatelle // used as in the API
@@ -428,21 +444,39 @@ type ErrorResponse struct {
 	Reason string `json:"reason"`
 	// Detail is more detailed description of the error.
 	Detail string `json:"detail,omitempty"`
+	// Parameters are the values interpolated in Detail, like the ID of a resource not found.
+	Parameters []interface{} `json:"parameters,omitempty"`
 }
 
 func (r *ErrorResponse) Error() string {
+	if r.Response == nil || r.Response.Request == nil {
+		return strings.TrimSpace(fmt.Sprintf("(request %q) %v", r.ErrorCode, r.Detail))
+	}
 	return fmt.Sprintf("%v %v: %d (request %q) %v",
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.ErrorCode, r.Detail)
 }
 
+// Is reports whether target is an *ErrorResponse with the same ErrorCode,
+// so errors.Is(err, ErrGroupNotFound) matches whatever the detail of the error is.
 func (r *ErrorResponse) Is(target error) bool {
 	var v *ErrorResponse
 
 	return errors.As(target, &v) &&
-		r.ErrorCode == v.ErrorCode &&
-		r.HTTPCode == v.HTTPCode &&
-		r.Reason == v.Reason &&
-		r.Detail == v.Detail
+		r.ErrorCode != "" &&
+		r.ErrorCode == v.ErrorCode
+}
+
+// Code returns the ErrorCode of the error, like ErrorCodeGroupNotFound.
+func (r *ErrorResponse) Code() ErrorCode {
+	return ErrorCode(r.ErrorCode)
+}
+
+// statusCode returns the HTTP status code of the error, read from the response if the body didn't have it.
+func (r *ErrorResponse) statusCode() int {
+	if r.HTTPCode == 0 && r.Response != nil {
+		return r.Response.StatusCode
+	}
+	return r.HTTPCode
 }
 
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
@@ -478,21 +512,10 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.send(ctx, req)
 	if err != nil {
-		// If we got an error, and the context has been canceled,
-		// the context's error is probably more useful.
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
 		return nil, err
 	}
-	if c.onRequestCompleted != nil {
-		c.onRequestCompleted(req, resp)
-	}
 
 	defer resp.Body.Close()
 
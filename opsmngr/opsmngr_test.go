@@ -377,6 +377,66 @@ func TestClient_Do_httpError(t *testing.T) {
 	}
 }
 
+func TestClient_Do_errorResponse(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{
+			"detail": "No group with name \"foo\" exists.",
+			"error": 404,
+			"errorCode": "GROUP_NAME_NOT_FOUND",
+			"parameters": ["foo"],
+			"reason": "Not Found"
+		}`)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, ".", nil)
+	_, err := client.Do(context.Background(), req, nil)
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("expected an *ErrorResponse, got %v", err)
+	}
+	if diff := deep.Equal(errResp.Parameters, []interface{}{"foo"}); diff != nil {
+		t.Error(diff)
+	}
+	if errResp.Code() != ErrorCodeGroupNameNotFound {
+		t.Errorf("Code() = %s, expected %s", errResp.Code(), ErrorCodeGroupNameNotFound)
+	}
+	if !errors.Is(err, ErrGroupNameNotFound) {
+		t.Errorf("expected %v to be %v", err, ErrGroupNameNotFound)
+	}
+	if errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected %v not to be %v", err, ErrGroupNotFound)
+	}
+	if !IsNotFound(err) || IsConflict(err) || IsRateLimited(err) {
+		t.Errorf("expected %v to be a not found error only", err)
+	}
+}
+
+func TestIsRateLimited(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, ".", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if !IsRateLimited(err) {
+		t.Errorf("expected %v to be rate limited", err)
+	}
+	if !IsRateLimited(fmt.Errorf("wrapped: %w", &ErrorResponse{ErrorCode: "RATE_LIMITED"})) {
+		t.Error("expected a RATE_LIMITED error to be rate limited")
+	}
+	if errors.Is(err, &ErrorResponse{}) {
+		t.Error("expected an error without error code not to match an empty ErrorResponse")
+	}
+}
+
 // Test handling of an error caused by the internal http client's Do()
 // function.
 func TestClient_Do_redirectLoop(t *testing.T) {
@@ -189,6 +189,10 @@ func (s *OrganizationsServiceOp) Projects(ctx context.Context, orgID string, opt
 		return nil, resp, err
 	}
 
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
 	return root, resp, err
 }
 
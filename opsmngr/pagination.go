@@ -0,0 +1,214 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// ErrNoMorePages is returned by Pager.Next when all pages have already been retrieved.
+var ErrNoMorePages = errors.New("no more pages")
+
+// PageFunc retrieves a single page of results for the given ListOptions.
+//
+// It is usually a thin wrapper around a List method of one of the services of the Client, for example:
+//
+//	func(ctx context.Context, opts *opsmngr.ListOptions) ([]*opsmngr.Project, *opsmngr.Response, error) {
+//		projects, resp, err := client.Projects.List(ctx, opts)
+//		if err != nil {
+//			return nil, resp, err
+//		}
+//		return projects.Results, resp, nil
+//	}
+type PageFunc[T any] func(context.Context, *ListOptions) ([]T, *Response, error)
+
+// PagerOpt are options for NewPager.
+type PagerOpt func(*pagerConfig)
+
+type pagerConfig struct {
+	prefetch bool
+}
+
+// WithPrefetch is a pager option to retrieve the next page concurrently while the current one is being consumed.
+// The prefetch isn't canceled with the ctx of the Next call that started it, so every call can use its own ctx,
+// call Pager.Close to cancel it when stopping before the last page.
+func WithPrefetch() PagerOpt {
+	return func(c *pagerConfig) {
+		c.prefetch = true
+	}
+}
+
+// Pager iterates over the pages of a paginated List method by following the "next" link relation
+// returned by Ops Manager. A Pager is not safe for concurrent use by multiple goroutines.
+type Pager[T any] struct {
+	fetch    PageFunc[T]
+	opts     ListOptions
+	prefetch bool
+	done     bool
+	pending  chan page[T]
+	cancel   context.CancelFunc // cancels the pending prefetch
+}
+
+type page[T any] struct {
+	items []T
+	resp  *Response
+	err   error
+}
+
+// NewPager returns a new Pager starting at the page set in opts, or at the first page if opts is nil.
+func NewPager[T any](fetch PageFunc[T], opts *ListOptions, pagerOpts ...PagerOpt) *Pager[T] {
+	cfg := &pagerConfig{}
+	for _, opt := range pagerOpts {
+		opt(cfg)
+	}
+
+	p := &Pager[T]{
+		fetch:    fetch,
+		prefetch: cfg.prefetch,
+	}
+	if opts != nil {
+		p.opts = *opts
+	}
+
+	return p
+}
+
+// HasNext reports whether there are pages left to retrieve.
+func (p *Pager[T]) HasNext() bool {
+	return !p.done
+}
+
+// Next retrieves the next page of results.
+// It returns ErrNoMorePages once the last page has been retrieved.
+// If ctx is canceled or times out, ctx.Err() will be returned.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, *Response, error) {
+	if p.done {
+		return nil, nil, ErrNoMorePages
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var current page[T]
+	if p.pending != nil {
+		select {
+		case current = <-p.pending:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		p.cancel()
+		p.pending, p.cancel = nil, nil
+	} else {
+		current = p.get(ctx, p.opts)
+	}
+
+	if current.err != nil {
+		p.done = true
+		return nil, current.resp, current.err
+	}
+
+	pageNum, ok := nextPageNum(current.resp)
+	if !ok {
+		p.done = true
+		return current.items, current.resp, nil
+	}
+	p.opts.PageNum = pageNum
+
+	if p.prefetch {
+		// the next page is for the next call, which may use another ctx,
+		// so the prefetch outlives this one while keeping its values until Close
+		var prefetchCtx context.Context
+		prefetchCtx, p.cancel = context.WithCancel(context.WithoutCancel(ctx))
+		p.pending = make(chan page[T], 1)
+		go func(ctx context.Context, opts ListOptions, pending chan<- page[T]) {
+			pending <- p.get(ctx, opts)
+		}(prefetchCtx, p.opts, p.pending)
+	}
+
+	return current.items, current.resp, nil
+}
+
+// Close stops the pager, canceling the prefetch of the next page if any.
+// Next returns ErrNoMorePages once the pager is closed.
+func (p *Pager[T]) Close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.pending, p.cancel = nil, nil
+	p.done = true
+}
+
+// All retrieves every remaining page and returns all the results.
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	err := p.ForEach(ctx, func(item T) error {
+		all = append(all, item)
+		return nil
+	})
+
+	return all, err
+}
+
+// ForEach calls f for every result of every remaining page.
+// Iteration stops at the first error returned by f, which closes the pager, or while retrieving a page.
+func (p *Pager[T]) ForEach(ctx context.Context, f func(T) error) error {
+	for p.HasNext() {
+		items, _, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := f(item); err != nil {
+				p.Close()
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Pager[T]) get(ctx context.Context, opts ListOptions) page[T] {
+	items, resp, err := p.fetch(ctx, &opts)
+	return page[T]{items: items, resp: resp, err: err}
+}
+
+// nextPageNum returns the page number of the "next" link relation of the response, if any.
+func nextPageNum(resp *Response) (int, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	link := resp.getLinkByRef("next")
+	if link == nil {
+		return 0, false
+	}
+
+	pageNumStr, err := link.getHrefQueryParam("pageNum")
+	if err != nil {
+		return 0, false
+	}
+	if pageNum, err := strconv.Atoi(pageNumStr); err == nil {
+		return pageNum, true
+	}
+	// the next link should always include the page number, fallback to the one after the current page
+	current, err := resp.CurrentPage()
+	if err != nil {
+		return 0, false
+	}
+
+	return current + 1, true
+}
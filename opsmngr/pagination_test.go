@@ -0,0 +1,216 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+// handlePagedProjects serves three pages of one project each, linking every page to the next one.
+func handlePagedProjects(t *testing.T, mux *http.ServeMux) {
+	t.Helper()
+	mux.HandleFunc("/api/public/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		pageNum := r.URL.Query().Get("pageNum")
+		if pageNum == "" {
+			pageNum = "1"
+		}
+		next := ""
+		if pageNum != "3" {
+			next = fmt.Sprintf(`,{"href": "http://mms:8080/api/public/v1.0/groups?pageNum=%c&itemsPerPage=1", "rel": "next"}`, pageNum[0]+1)
+		}
+		_, _ = fmt.Fprintf(w, `{
+			"links": [{"href": "http://mms:8080/api/public/v1.0/groups?pageNum=%s&itemsPerPage=1", "rel": "self"}%s],
+			"results": [{"id": "project%s"}],
+			"totalCount": 3
+		}`, pageNum, next, pageNum)
+	})
+}
+
+func listProjects(client *Client) PageFunc[*Project] {
+	return func(ctx context.Context, opts *ListOptions) ([]*Project, *Response, error) {
+		projects, resp, err := client.Projects.List(ctx, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		return projects.Results, resp, nil
+	}
+}
+
+func TestPager_All(t *testing.T) {
+	expected := []*Project{{ID: "project1"}, {ID: "project2"}, {ID: "project3"}}
+
+	t.Run("sequential", func(t *testing.T) {
+		client, mux, teardown := setup()
+		defer teardown()
+		handlePagedProjects(t, mux)
+
+		projects, err := NewPager(listProjects(client), &ListOptions{ItemsPerPage: 1}).All(ctx)
+		if err != nil {
+			t.Fatalf("Pager.All returned error: %v", err)
+		}
+		if diff := deep.Equal(projects, expected); diff != nil {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("prefetch", func(t *testing.T) {
+		client, mux, teardown := setup()
+		defer teardown()
+		handlePagedProjects(t, mux)
+
+		projects, err := NewPager(listProjects(client), nil, WithPrefetch()).All(ctx)
+		if err != nil {
+			t.Fatalf("Pager.All returned error: %v", err)
+		}
+		if diff := deep.Equal(projects, expected); diff != nil {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestPager_Next(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	handlePagedProjects(t, mux)
+
+	pager := NewPager(listProjects(client), &ListOptions{PageNum: 2})
+	projects, resp, err := pager.Next(ctx)
+	if err != nil {
+		t.Fatalf("Pager.Next returned error: %v", err)
+	}
+	if diff := deep.Equal(projects, []*Project{{ID: "project2"}}); diff != nil {
+		t.Error(diff)
+	}
+	if resp.IsLastPage() {
+		t.Error("expected more pages")
+	}
+
+	if _, resp, err = pager.Next(ctx); err != nil {
+		t.Fatalf("Pager.Next returned error: %v", err)
+	}
+	if !resp.IsLastPage() || pager.HasNext() {
+		t.Error("expected last page")
+	}
+
+	if _, _, err = pager.Next(ctx); !errors.Is(err, ErrNoMorePages) {
+		t.Errorf("expected %v, got %v", ErrNoMorePages, err)
+	}
+}
+
+func TestPager_Next_prefetchWithPerCallContext(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	handlePagedProjects(t, mux)
+
+	// the prefetched pages are retrieved once the ctx of the call that started them is canceled
+	proceed := make(chan struct{}, 1)
+	fetch := func(ctx context.Context, opts *ListOptions) ([]*Project, *Response, error) {
+		if opts.PageNum > 1 {
+			<-proceed
+		}
+		return listProjects(client)(ctx, opts)
+	}
+
+	pager := NewPager(fetch, &ListOptions{ItemsPerPage: 1}, WithPrefetch())
+	var projects []*Project
+	for i := 0; pager.HasNext(); i++ {
+		if i > 0 {
+			proceed <- struct{}{}
+		}
+		callCtx, cancel := context.WithCancel(ctx)
+		items, _, err := pager.Next(callCtx)
+		cancel()
+		if err != nil {
+			t.Fatalf("Pager.Next returned error: %v", err)
+		}
+		projects = append(projects, items...)
+	}
+	if diff := deep.Equal(projects, []*Project{{ID: "project1"}, {ID: "project2"}, {ID: "project3"}}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestPager_Close(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	handlePagedProjects(t, mux)
+
+	// the prefetch of the second page blocks until it's canceled
+	canceled := make(chan struct{})
+	fetch := func(ctx context.Context, opts *ListOptions) ([]*Project, *Response, error) {
+		if opts.PageNum > 1 {
+			<-ctx.Done()
+			close(canceled)
+			return nil, nil, ctx.Err()
+		}
+		return listProjects(client)(ctx, opts)
+	}
+	stop := errors.New("stop")
+
+	pager := NewPager(fetch, &ListOptions{ItemsPerPage: 1}, WithPrefetch())
+	err := pager.ForEach(ctx, func(*Project) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected %v, got %v", stop, err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected the prefetch to be canceled")
+	}
+	if _, _, err := pager.Next(ctx); !errors.Is(err, ErrNoMorePages) {
+		t.Errorf("expected %v once closed, got %v", ErrNoMorePages, err)
+	}
+}
+
+func TestPager_canceledContext(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	handlePagedProjects(t, mux)
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := NewPager(listProjects(client), nil).All(canceledCtx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestPager_error(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/public/v1.0/groups", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+	})
+
+	pager := NewPager(listProjects(client), nil)
+	if _, err := pager.All(ctx); err == nil {
+		t.Error("expected an error")
+	}
+	if pager.HasNext() {
+		t.Error("expected the pager to stop after an error")
+	}
+}
@@ -89,8 +89,15 @@ func (s *ProjectJobConfigServiceOp) List(ctx context.Context, options *ListOptio
 
 	root := new(ProjectJobs)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Get retrieves the configuration of one project’s backup jobs.
@@ -343,5 +343,9 @@ func (s *ProjectsServiceOp) GetTeams(ctx context.Context, projectID string, opts
 		return nil, resp, err
 	}
 
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
 	return root, resp, err
 }
@@ -0,0 +1,255 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit defines a token bucket allowing Rate requests per second with bursts of up to Burst requests.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitMetrics reports how a request went through a RateLimiter.
+type RateLimitMetrics struct {
+	Request *http.Request
+	// Prefix of the path limit applied to the request, empty when only the global limit applies.
+	Prefix string
+	// Wait is how long the request was delayed by the limiter.
+	Wait time.Duration
+	// Err is the context error if the request was canceled while waiting.
+	Err error
+}
+
+// RateLimitCallback defines the type of the rate limiter metrics callback function.
+type RateLimitCallback func(*RateLimitMetrics)
+
+// RateLimiterOpt are options for NewRateLimiter.
+type RateLimiterOpt func(*RateLimiter) error
+
+// RateLimiter is a client-side token bucket limiter with an optional global limit
+// and limits per path prefix. A RateLimiter is safe for concurrent use and can be
+// shared by several clients using the same API key.
+type RateLimiter struct {
+	global   *tokenBucket
+	prefixes []*prefixBucket
+	callback RateLimitCallback
+	now      func() time.Time
+}
+
+type prefixBucket struct {
+	prefix   string
+	segments []string
+	*tokenBucket
+}
+
+// NewRateLimiter returns a new RateLimiter.
+func NewRateLimiter(opts ...RateLimiterOpt) (*RateLimiter, error) {
+	l := &RateLimiter{now: time.Now}
+	for _, opt := range opts {
+		if err := opt(l); err != nil {
+			return nil, err
+		}
+	}
+	// the most specific prefix wins
+	sort.SliceStable(l.prefixes, func(i, j int) bool {
+		return len(l.prefixes[i].segments) > len(l.prefixes[j].segments)
+	})
+
+	return l, nil
+}
+
+// WithGlobalLimit is a rate limiter option to limit every request.
+func WithGlobalLimit(limit RateLimit) RateLimiterOpt {
+	return func(l *RateLimiter) error {
+		if err := validateRateLimit(limit); err != nil {
+			return err
+		}
+		l.global = newTokenBucket(limit, l.now())
+		return nil
+	}
+}
+
+// WithPathLimit is a rate limiter option to limit requests whose path, relative to the base URL of the client,
+// starts with prefix. A "*" segment matches any single path segment, for example
+// "api/public/v1.0/groups/*/hosts/*/measurements". Requests matching a path limit are subject to the global limit too.
+func WithPathLimit(prefix string, limit RateLimit) RateLimiterOpt {
+	return func(l *RateLimiter) error {
+		if prefix == "" {
+			return NewArgError("prefix", "must be set")
+		}
+		if err := validateRateLimit(limit); err != nil {
+			return err
+		}
+		l.prefixes = append(l.prefixes, &prefixBucket{
+			prefix:      prefix,
+			segments:    pathSegments(prefix),
+			tokenBucket: newTokenBucket(limit, l.now()),
+		})
+		return nil
+	}
+}
+
+// WithRateLimitCallback is a rate limiter option to receive the metrics of every request.
+func WithRateLimitCallback(callback RateLimitCallback) RateLimiterOpt {
+	return func(l *RateLimiter) error {
+		l.callback = callback
+		return nil
+	}
+}
+
+// SetRateLimiter is a client option for limiting the rate of requests of all the services of the client.
+func SetRateLimiter(l *RateLimiter) ClientOpt {
+	return func(c *Client) error {
+		if l == nil {
+			return NewArgError("rateLimiter", "must be set")
+		}
+		c.rateLimiter = l
+		return nil
+	}
+}
+
+func validateRateLimit(limit RateLimit) error {
+	if limit.Rate <= 0 {
+		return NewArgError("Rate", "must be greater than zero")
+	}
+	if limit.Burst < 1 {
+		return NewArgError("Burst", "must be at least one")
+	}
+	return nil
+}
+
+// wait blocks until the request is allowed by every limit matching path or ctx is done.
+func (l *RateLimiter) wait(ctx context.Context, req *http.Request, path string) error {
+	if l == nil {
+		return nil
+	}
+
+	var buckets []*tokenBucket
+	metrics := &RateLimitMetrics{Request: req}
+	if b := l.match(path); b != nil {
+		metrics.Prefix = b.prefix
+		buckets = append(buckets, b.tokenBucket)
+	}
+	if l.global != nil {
+		buckets = append(buckets, l.global)
+	}
+
+	now := l.now()
+	for _, b := range buckets {
+		if d := b.reserve(now); d > metrics.Wait {
+			metrics.Wait = d
+		}
+	}
+
+	if metrics.Wait > 0 {
+		timer := time.NewTimer(metrics.Wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			for _, b := range buckets {
+				b.release()
+			}
+			metrics.Err = ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if l.callback != nil {
+		l.callback(metrics)
+	}
+
+	return metrics.Err
+}
+
+func (l *RateLimiter) match(path string) *prefixBucket {
+	segments := pathSegments(path)
+	for _, b := range l.prefixes {
+		if matchSegments(b.segments, segments) {
+			return b
+		}
+	}
+	return nil
+}
+
+func pathSegments(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) > len(segments) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenBucket implements a token bucket where tokens can be reserved in advance.
+type tokenBucket struct {
+	mu     sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   now,
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.limit.Rate
+		if burst := float64(b.limit.Burst); b.tokens > burst {
+			b.tokens = burst
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.limit.Rate * float64(time.Second))
+}
+
+// release gives back a token reserved by a request that was canceled before being sent.
+func (b *tokenBucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if burst := float64(b.limit.Burst); b.tokens > burst {
+		b.tokens = burst
+	}
+}
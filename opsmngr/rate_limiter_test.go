@@ -0,0 +1,140 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Do_rateLimiter(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	var metrics []*RateLimitMetrics
+	limiter, err := NewRateLimiter(
+		WithGlobalLimit(RateLimit{Rate: 1000, Burst: 10}),
+		WithPathLimit("api/public/v1.0/groups/*/hosts", RateLimit{Rate: 5, Burst: 1}),
+		WithRateLimitCallback(func(m *RateLimitMetrics) {
+			metrics = append(metrics, m)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+	if err = SetRateLimiter(limiter)(client); err != nil {
+		t.Fatalf("SetRateLimiter returned error: %v", err)
+	}
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"api/public/v1.0/groups", "api/public/v1.0/groups/1/hosts", "api/public/v1.0/groups/1/hosts/2"} {
+		req, _ := client.NewRequest(ctx, http.MethodGet, path, nil)
+		if _, err = client.Do(ctx, req, nil); err != nil {
+			t.Fatalf("Do(): %v", err)
+		}
+	}
+
+	if len(metrics) != 3 {
+		t.Fatalf("got %d metrics, expected %d", len(metrics), 3)
+	}
+	if metrics[0].Prefix != "" || metrics[0].Wait != 0 {
+		t.Errorf("expected the first request to only go through the global limit, got %+v", metrics[0])
+	}
+	if metrics[1].Prefix != "api/public/v1.0/groups/*/hosts" || metrics[1].Wait != 0 {
+		t.Errorf("expected the second request to use the burst of the path limit, got %+v", metrics[1])
+	}
+	if metrics[2].Wait == 0 {
+		t.Errorf("expected the third request to wait for the path limit, got %+v", metrics[2])
+	}
+}
+
+func TestRateLimiter_canceledContext(t *testing.T) {
+	limiter, err := NewRateLimiter(WithGlobalLimit(RateLimit{Rate: 0.001, Burst: 1}))
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+
+	if err = limiter.wait(ctx, nil, "foo"); err != nil {
+		t.Fatalf("wait returned error: %v", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err = limiter.wait(timeoutCtx, nil, "foo"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if tokens := limiter.global.tokens; tokens < 0 {
+		t.Errorf("expected the token of the canceled request to be released, got %v tokens", tokens)
+	}
+}
+
+func TestTokenBucket_reserve(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(RateLimit{Rate: 2, Burst: 2}, now)
+
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(now); d != 0 {
+			t.Errorf("reserve() = %v, expected burst to allow the request", d)
+		}
+	}
+	if d := b.reserve(now); d != 500*time.Millisecond {
+		t.Errorf("reserve() = %v, expected %v", d, 500*time.Millisecond)
+	}
+	// one token refilled after half a second is already reserved
+	if d := b.reserve(now.Add(500 * time.Millisecond)); d != 500*time.Millisecond {
+		t.Errorf("reserve() = %v, expected %v", d, 500*time.Millisecond)
+	}
+}
+
+func TestNewRateLimiter_invalid(t *testing.T) {
+	opts := []RateLimiterOpt{
+		WithGlobalLimit(RateLimit{Rate: 0, Burst: 1}),
+		WithGlobalLimit(RateLimit{Rate: 1, Burst: 0}),
+		WithPathLimit("", RateLimit{Rate: 1, Burst: 1}),
+	}
+	for _, opt := range opts {
+		if _, err := NewRateLimiter(opt); err == nil {
+			t.Error("expected an error")
+		}
+	}
+	if _, err := New(nil, SetRateLimiter(nil)); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "api/public/v1.0/groups", path: "api/public/v1.0/groups", want: true},
+		{pattern: "api/public/v1.0/groups", path: "api/public/v1.0/groups/1/hosts", want: true},
+		{pattern: "api/public/v1.0/groups/*/hosts", path: "api/public/v1.0/groups/1/hosts/2", want: true},
+		{pattern: "api/public/v1.0/groups/*/hosts", path: "api/public/v1.0/groups/1/alerts", want: false},
+		{pattern: "api/public/v1.0/groups/*/hosts", path: "api/public/v1.0/groups", want: false},
+	}
+	for _, tt := range tests {
+		if got := matchSegments(pathSegments(tt.pattern), pathSegments(tt.path)); got != tt.want {
+			t.Errorf("matchSegments(%q, %q) = %v, expected %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultMaxRetries    = 3
+	defaultMinBackoff    = 1 * time.Second
+	defaultMaxBackoff    = 30 * time.Second
+	maxDrainedBodyLength = 4096
+)
+
+// RetryPolicy defines how Client.Do retries idempotent requests that failed because of
+// rate limiting (429), an unavailable server (502, 503 and 504), a refused connection, a timeout
+// or a reset connection, like while Ops Manager restarts.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+	// MinBackoff is the wait before the first retry, it doubles on every following retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the backoff, including the wait requested by a Retry-After header.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with 3 retries and a backoff between 1 and 30 seconds.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: defaultMaxRetries,
+		MinBackoff: defaultMinBackoff,
+		MaxBackoff: defaultMaxBackoff,
+	}
+}
+
+// SetRetryPolicy is a client option for retrying failed idempotent requests with exponential backoff.
+// Every attempt is reported to the OnRequestCompleted callback.
+func SetRetryPolicy(p *RetryPolicy) ClientOpt {
+	return func(c *Client) error {
+		if p == nil {
+			return NewArgError("retryPolicy", "must be set")
+		}
+		if p.MaxRetries < 0 {
+			return NewArgError("MaxRetries", "must be a positive number")
+		}
+		if p.MinBackoff < 0 || p.MaxBackoff < p.MinBackoff {
+			return NewArgError("MaxBackoff", "must be greater than MinBackoff")
+		}
+
+		c.retryPolicy = p
+		return nil
+	}
+}
+
+// do sends the request with the underlying HTTPClient, logging it if the client has a logger.
+func (c *Client) do(ctx context.Context, req *http.Request, attempt int) (*http.Response, error) {
+	if c.logger == nil {
+		return c.client.Do(req)
+	}
+	return c.logger.do(ctx, c.client, req, attempt)
+}
+
+// send submits the request with the underlying HTTPClient once allowed by the rate limiter of the client,
+// retrying according to the retry policy of the client.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	path := strings.TrimPrefix(req.URL.Path, c.BaseURL.Path)
+	for attempt := 0; ; attempt++ {
+		if err := c.rateLimiter.wait(ctx, req, path); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.do(ctx, req, attempt)
+		if err != nil {
+			// If we got an error, and the context has been canceled,
+			// the context's error is probably more useful.
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+		} else if c.onRequestCompleted != nil {
+			c.onRequestCompleted(req, resp)
+		}
+
+		if !c.retryPolicy.shouldRetry(req, resp, err, attempt) {
+			return resp, err
+		}
+
+		wait := c.retryPolicy.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBodyLength))
+			resp.Body.Close()
+		}
+
+		if req.Body != nil && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxRetries || !isIdempotent(req.Method) {
+		return false
+	}
+	// bodies that can't be rewound can only be sent once
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return isTransientError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns how long to wait before the next attempt.
+// Retry-After is honored when present, up to MaxBackoff, otherwise an exponential backoff with jitter is used.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return wait
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := 0; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// equal jitter, wait at least half of the backoff
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1)) //nolint:gosec // jitter doesn't need a secure random number
+}
+
+// parseRetryAfter parses a Retry-After header in either its delay-seconds or HTTP-date form.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransientError reports whether the request failed because of a refused connection, a timeout
+// or a connection reset. Errors dialing the server are safe to retry as nothing was sent yet,
+// except unknown hosts which are unlikely to go away.
+func isTransientError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		var dnsErr *net.DNSError
+		return !errors.As(err, &dnsErr) || !dnsErr.IsNotFound
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
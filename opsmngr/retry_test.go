@@ -0,0 +1,262 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = &RetryPolicy{
+	MaxRetries: 2,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: 5 * time.Millisecond,
+}
+
+func TestClient_Do_retry(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	if err := SetRetryPolicy(testRetryPolicy)(client); err != nil {
+		t.Fatalf("SetRetryPolicy returned error: %v", err)
+	}
+
+	attempts := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"A":"a"}`+"\n" {
+			t.Errorf("Request body = %s, expected the body to be sent on every attempt", body)
+		}
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprint(w, testResponse)
+	})
+
+	completed := 0
+	client.OnRequestCompleted(func(*http.Request, *http.Response) {
+		completed++
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodPut, ".", map[string]string{"A": "a"})
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("attempts = %d, expected %d", attempts, 2)
+	}
+	if completed != 2 {
+		t.Errorf("OnRequestCompleted called %d times, expected %d", completed, 2)
+	}
+}
+
+func TestClient_Do_retryExhausted(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	if err := SetRetryPolicy(testRetryPolicy)(client); err != nil {
+		t.Fatalf("SetRetryPolicy returned error: %v", err)
+	}
+
+	attempts := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, ".", nil)
+	resp, err := client.Do(ctx, req, nil)
+	if err == nil {
+		t.Fatal("Expected HTTP 429 error.")
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("StatusCode = %d, expected %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if attempts != testRetryPolicy.MaxRetries+1 {
+		t.Errorf("attempts = %d, expected %d", attempts, testRetryPolicy.MaxRetries+1)
+	}
+}
+
+func TestClient_Do_noRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		policy *RetryPolicy
+	}{
+		{name: "no policy", method: http.MethodGet},
+		{name: "not idempotent", method: http.MethodPost, policy: testRetryPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, teardown := setup()
+			defer teardown()
+			client.retryPolicy = tt.policy
+
+			attempts := 0
+			mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				w.WriteHeader(http.StatusBadGateway)
+			})
+
+			req, _ := client.NewRequest(ctx, tt.method, ".", nil)
+			if _, err := client.Do(ctx, req, nil); err == nil {
+				t.Error("Expected HTTP 502 error.")
+			}
+			if attempts != 1 {
+				t.Errorf("attempts = %d, expected %d", attempts, 1)
+			}
+		})
+	}
+}
+
+func TestClient_Do_retryNoBody(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	if err := SetRetryPolicy(testRetryPolicy)(client); err != nil {
+		t.Fatalf("SetRetryPolicy returned error: %v", err)
+	}
+
+	attempts := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprint(w, testResponse)
+	})
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, client.BaseURL.String(), http.NoBody)
+	if req.GetBody != nil {
+		t.Fatal("expected a request without GetBody")
+	}
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, expected %d", attempts, 2)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"connection reset":   {err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, expected: true},
+		"timeout":            {err: &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}, expected: true},
+		"unexpected EOF":     {err: fmt.Errorf("reading response: %w", io.ErrUnexpectedEOF), expected: true},
+		"connection refused": {err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, expected: true},
+		"unreachable":        {err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)}, expected: true},
+		"closed connection":  {err: &net.OpError{Op: "write", Net: "tcp", Err: net.ErrClosed}},
+		"unknown host":       {err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "opsmanager.invalid", IsNotFound: true}}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.expected {
+				t.Errorf("isTransientError(%v) = %t, expected %t", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClient_Do_retryCanceledContext(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+	if err := SetRetryPolicy(&RetryPolicy{MaxRetries: 1, MinBackoff: time.Hour, MaxBackoff: time.Hour})(client); err != nil {
+		t.Fatalf("SetRetryPolicy returned error: %v", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGatewayTimeout)
+	})
+
+	req, _ := client.NewRequest(timeoutCtx, http.MethodGet, ".", nil)
+	if _, err := client.Do(timeoutCtx, req, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestSetRetryPolicy_invalid(t *testing.T) {
+	policies := []*RetryPolicy{
+		nil,
+		{MaxRetries: -1},
+		{MaxRetries: 1, MinBackoff: time.Second, MaxBackoff: time.Millisecond},
+	}
+	for _, p := range policies {
+		if _, err := New(nil, SetRetryPolicy(p)); err == nil {
+			t.Errorf("expected an error for %+v", p)
+		}
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MaxRetries: 10, MinBackoff: time.Second, MaxBackoff: 4 * time.Second}
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{attempt: 0, min: 500 * time.Millisecond, max: time.Second},
+		{attempt: 1, min: time.Second, max: 2 * time.Second},
+		{attempt: 2, min: 2 * time.Second, max: 4 * time.Second},
+		{attempt: 9, min: 2 * time.Second, max: 4 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.backoff(tt.attempt, nil); got < tt.min || got > tt.max {
+			t.Errorf("backoff(%d) = %v, expected between %v and %v", tt.attempt, got, tt.min, tt.max)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {"3"}}}
+	if got := p.backoff(0, resp); got != 3*time.Second {
+		t.Errorf("backoff() = %v, expected the Retry-After value %v", got, 3*time.Second)
+	}
+	resp.Header.Set("Retry-After", "120")
+	if got := p.backoff(0, resp); got != p.MaxBackoff {
+		t.Errorf("backoff() = %v, expected the Retry-After value capped to %v", got, p.MaxBackoff)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	tests := []struct {
+		value string
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "10", ok: true},
+		{value: "-1", ok: false},
+		{value: future, ok: true},
+		{value: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		if _, ok := parseRetryAfter(tt.value); ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, expected %v", tt.value, ok, tt.ok)
+		}
+	}
+}
@@ -96,8 +96,15 @@ func (s *S3BlockstoreConfigServiceOp) List(ctx context.Context, options *ListOpt
 
 	root := new(S3Blockstores)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Create create a S3Blockstore.
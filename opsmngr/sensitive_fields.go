@@ -0,0 +1,99 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"sort"
+	"strings"
+)
+
+// sensitiveFields are the JSON fields of the types of this library holding secrets.
+var sensitiveFields = map[string]bool{
+	// automation config
+	"autoPwd":                    true,
+	"newAutoPwd":                 true,
+	"pwd":                        true,
+	"initPwd":                    true,
+	"scramSha1Creds":             true,
+	"scramSha256Creds":           true,
+	"bindQueryPassword":          true,
+	"certificateKeyFilePassword": true,
+	"clusterPassword":            true,
+	"autoPEMKeyFilePwd":          true,
+	"passwordHash":               true,
+	"passwordSalt":               true,
+	"tlsPemPassword":             true,
+	// API keys, users and agents
+	"privateKey":              true,
+	"password":                true,
+	"agentApiKey":             true,
+	"sslPEMKeyPwd":            true,
+	"kerberosKeytab":          true, // path of the agent keytab, kept out of logs with the other agent credentials
+	"kerberosWindowsPassword": true,
+	// backup
+	"awsSecretKey":           true,
+	"kmipClientCertPassword": true,
+	// alert notifications
+	"apiToken":         true,
+	"datadogApiKey":    true,
+	"flowdockApiToken": true,
+	"opsGenieApiKey":   true,
+	"serviceKey":       true,
+	"victorOpsApiKey":  true,
+	"webhookSecret":    true,
+	// live migration
+	"linkToken": true,
+	// device flow of the auth package, in form bodies and JSON responses
+	"access_token":  true,
+	"refresh_token": true,
+	"device_code":   true,
+	"token":         true,
+}
+
+// SensitiveFields returns the sorted JSON fields of the types of this library holding secrets,
+// like passwords of the automation config or private keys of API keys, which should never be logged or stored.
+func SensitiveFields() []string {
+	fields := make([]string, 0, len(sensitiveFields))
+	for f := range sensitiveFields {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// sensitivePaths are the JSON paths of the secrets whose field name is too common to be redacted everywhere.
+var sensitivePaths = map[string]bool{
+	"auth.key": true, // contents of the keyfile, unlike the keys of indexConfigs
+	"key":      true, // agent API keys
+}
+
+// IsSensitiveField reports whether the JSON field name holds a secret.
+func IsSensitiveField(name string) bool {
+	return sensitiveFields[name]
+}
+
+// IsSensitivePath reports whether the JSON field at path, like auth.key or auth.usersWanted.pwd, holds a secret.
+// Paths are relative to the root of the JSON document and don't include array indexes.
+func IsSensitivePath(path string) bool {
+	if sensitivePaths[path] {
+		return true
+	}
+	for _, field := range strings.Split(path, ".") {
+		if sensitiveFields[field] {
+			return true
+		}
+	}
+	return false
+}
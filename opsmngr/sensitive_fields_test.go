@@ -0,0 +1,34 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import "testing"
+
+func TestIsSensitivePath(t *testing.T) {
+	testCases := map[string]bool{
+		"auth.key":                               true,
+		"auth.usersWanted.pwd":                   true,
+		"auth.usersWanted.scramSha256Creds.salt": true,
+		"key":                                    true,
+		"indexConfigs.key":                       false,
+		"auth.keyfile":                           false,
+		"processes.args2_6.net.port":             false,
+	}
+	for path, want := range testCases {
+		if got := IsSensitivePath(path); got != want {
+			t.Errorf("IsSensitivePath(%q) = %v, expected %v", path, got, want)
+		}
+	}
+}
@@ -142,8 +142,15 @@ func (s *ServerUsageServiceOp) ListAllHostAssignment(ctx context.Context, option
 
 	root := new(HostAssignments)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // ProjectHostAssignments retrieves all host assignments in a project.
@@ -167,8 +174,15 @@ func (s *ServerUsageServiceOp) ProjectHostAssignments(ctx context.Context, group
 
 	root := new(HostAssignments)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // OrganizationHostAssignments retrieves all host assignments in a organization.
@@ -192,8 +206,15 @@ func (s *ServerUsageServiceOp) OrganizationHostAssignments(ctx context.Context,
 
 	root := new(HostAssignments)
 	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // GetServerTypeProject retrieves one default server type for one project.
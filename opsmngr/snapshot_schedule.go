@@ -76,7 +76,11 @@ func (s *SnapshotScheduleServiceOp) Get(ctx context.Context, groupID, clusterID
 		return nil, resp, err
 	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Update updates the parameters of snapshot creation and retention.
@@ -90,7 +90,11 @@ func (s *UsersServiceOp) Get(ctx context.Context, userID string) (*User, *Respon
 		return nil, resp, err
 	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // GetByName gets a single user by name.
@@ -114,7 +118,11 @@ func (s *UsersServiceOp) GetByName(ctx context.Context, username string) (*User,
 		return nil, resp, err
 	}
 
-	return root, resp, err
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
 }
 
 // Create creates a new IAM user.
@@ -0,0 +1,92 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+const alertStatusOpen = "OPEN"
+
+// AddAlert adds an alert to the project groupID and returns it with its ID set,
+// the status of the alert defaults to OPEN.
+func (s *Server) AddAlert(groupID string, a *opsmngr.Alert) (*opsmngr.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, p := s.project(groupID)
+	if p == nil {
+		return nil, fmt.Errorf("project %s not found", groupID)
+	}
+	a = copyJSON(a)
+	a.ID = s.newID()
+	a.GroupID = p.ID
+	if a.Status == "" {
+		a.Status = alertStatusOpen
+	}
+	p.alerts = append(p.alerts, a)
+	return copyJSON(a), nil
+}
+
+func (s *Server) alertRoutes() {
+	s.handle(http.MethodGet, "/groups/{groupID}/alerts", s.withProject(s.listAlerts))
+	s.handle(http.MethodGet, "/groups/{groupID}/alerts/{alertID}", s.withProject(s.withAlert(s.getAlert)))
+	s.handle(http.MethodPatch, "/groups/{groupID}/alerts/{alertID}", s.withProject(s.withAlert(s.acknowledgeAlert)))
+}
+
+// withAlert calls f with the alert of the alertID parameter, or writes a not found error.
+func (s *Server) withAlert(f func(http.ResponseWriter, *http.Request, *opsmngr.Alert)) func(http.ResponseWriter, *http.Request, params, *project) {
+	return func(w http.ResponseWriter, r *http.Request, params params, p *project) {
+		for _, a := range p.alerts {
+			if a.ID == params["alertID"] {
+				f(w, r, a)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, opsmngr.ErrorCodeAlertNotFound, "No alert with ID %s exists in group %s.", params["alertID"], p.ID)
+	}
+}
+
+func (s *Server) listAlerts(w http.ResponseWriter, r *http.Request, _ params, p *project) {
+	status := r.URL.Query().Get("status")
+	alerts := []opsmngr.Alert{}
+	for _, a := range p.alerts {
+		if status == "" || a.Status == status {
+			alerts = append(alerts, *a)
+		}
+	}
+	start, end, links := page(r, len(alerts))
+	writeJSON(w, http.StatusOK, &opsmngr.AlertsResponse{Links: links, Results: alerts[start:end], TotalCount: len(alerts)})
+}
+
+func (s *Server) getAlert(w http.ResponseWriter, _ *http.Request, a *opsmngr.Alert) {
+	writeJSON(w, http.StatusOK, a)
+}
+
+// acknowledgeAlert sets or clears the acknowledgement of an alert, an empty acknowledgedUntil unacknowledges it.
+func (s *Server) acknowledgeAlert(w http.ResponseWriter, r *http.Request, a *opsmngr.Alert) {
+	req := new(opsmngr.AcknowledgeRequest)
+	if !readJSON(w, r, req) {
+		return
+	}
+	a.AcknowledgedUntil = ""
+	if req.AcknowledgedUntil != nil {
+		a.AcknowledgedUntil = *req.AcknowledgedUntil
+	}
+	a.AcknowledgementComment = req.AcknowledgementComment
+	writeJSON(w, http.StatusOK, a)
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+// planWhileApplying is the plan of processes that didn't reach the goal state yet.
+var planWhileApplying = []string{"ChangeVersion", "Start"}
+
+// AutomationConfig returns a copy of the current automation config of the project groupID.
+func (s *Server) AutomationConfig(groupID string) (*opsmngr.AutomationConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, p := s.project(groupID)
+	if p == nil {
+		return nil, fmt.Errorf("project %s not found", groupID)
+	}
+	return copyJSON(p.config), nil
+}
+
+// SetAutomationConfig replaces the automation config of the project groupID,
+// bumping its version, the processes are in the goal state right away.
+func (s *Server) SetAutomationConfig(groupID string, config *opsmngr.AutomationConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, p := s.project(groupID)
+	if p == nil {
+		return fmt.Errorf("project %s not found", groupID)
+	}
+	c := copyJSON(config)
+	c.Version = p.config.Version + 1
+	p.config = c
+	p.achieved, p.pending = c.Version, 0
+	return nil
+}
+
+func (s *Server) automationRoutes() {
+	s.handle(http.MethodGet, "/groups/{groupID}/automationConfig", s.withProject(s.getAutomationConfig))
+	s.handle(http.MethodPut, "/groups/{groupID}/automationConfig", s.withProject(s.updateAutomationConfig))
+	s.handle(http.MethodGet, "/groups/{groupID}/automationStatus", s.withProject(s.getAutomationStatus))
+}
+
+func (s *Server) getAutomationConfig(w http.ResponseWriter, _ *http.Request, _ params, p *project) {
+	writeJSON(w, http.StatusOK, p.config)
+}
+
+// updateAutomationConfig replaces the config if it's based on the current version, and bumps the version.
+func (s *Server) updateAutomationConfig(w http.ResponseWriter, r *http.Request, _ params, p *project) {
+	c := new(opsmngr.AutomationConfig)
+	if !readJSON(w, r, c) {
+		return
+	}
+	if c.Version != p.config.Version {
+		writeError(w, http.StatusConflict, opsmngr.ErrorCodeConflictingAutomationConfig,
+			"The automation config was updated to version %d since version %d was read.", p.config.Version, c.Version)
+		return
+	}
+	c.Version++
+	p.config = c
+	p.pending = s.convergeAfter
+	if p.pending == 0 {
+		p.achieved = c.Version
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// getAutomationStatus reports the processes of the config, they reach the current version
+// once the configured number of polls happened since the last update.
+func (s *Server) getAutomationStatus(w http.ResponseWriter, _ *http.Request, _ params, p *project) {
+	if p.pending > 0 {
+		p.pending--
+	} else {
+		p.achieved = p.config.Version
+	}
+
+	status := &opsmngr.AutomationStatus{
+		GoalVersion: p.config.Version,
+		Processes:   make([]opsmngr.ProcessStatus, 0, len(p.config.Processes)),
+	}
+	for _, proc := range p.config.Processes {
+		plan := []string{}
+		if p.achieved < p.config.Version {
+			plan = planWhileApplying
+		}
+		status.Processes = append(status.Processes, opsmngr.ProcessStatus{
+			Hostname:                proc.Hostname,
+			LastGoalVersionAchieved: p.achieved,
+			Name:                    proc.Name,
+			Plan:                    plan,
+		})
+	}
+	writeJSON(w, http.StatusOK, status)
+}
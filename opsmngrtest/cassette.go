@@ -0,0 +1,370 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+// Redacted replaces secrets in recorded interactions.
+const Redacted = "REDACTED"
+
+const (
+	cassetteFileMode = 0o600
+	formMediaType    = "application/x-www-form-urlencoded"
+)
+
+// ErrInteractionNotFound is returned by a Replayer for a request without a recorded interaction left.
+var ErrInteractionNotFound = errors.New("no recorded interaction")
+
+// sensitiveHeaders are recorded as Redacted.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Cassette is a list of recorded HTTP interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  *RecordedRequest  `json:"request"`
+	Response *RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded HTTP request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// LoadCassette reads a cassette from a file.
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := new(Cassette)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to a file.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), cassetteFileMode)
+}
+
+// Recorder is an http.RoundTripper recording the interactions of an underlying transport,
+// with secrets redacted, until Save writes them to a cassette.
+//
+// The Recorder should wrap the authenticating transport so that only the authenticated
+// exchanges are recorded:
+//
+//	r := opsmngrtest.NewRecorder("testdata/cassette.json", opsmngr.NewDigestTransport(publicKey, privateKey))
+//	client := opsmngr.NewClient(&http.Client{Transport: r})
+//	...
+//	err := r.Save()
+type Recorder struct {
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder saving to path, the transport defaults to http.DefaultTransport.
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{path: path, transport: transport}
+}
+
+// RoundTrip sends the request with the underlying transport and records the interaction.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	out, reqBody, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: &RecordedRequest{
+			Method: req.Method,
+			URL:    scrubURL(req.URL).String(),
+			Header: scrubHeader(req.Header),
+			Body:   scrubBody(req.Header, reqBody),
+		},
+		Response: &RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       scrubBody(resp.Header, respBody),
+		},
+	})
+	return resp, nil
+}
+
+// Save writes the interactions recorded so far to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Replayer is an opsmngr.HTTPClient answering requests with the interactions of a cassette.
+//
+// A request matches an interaction with the same method, path and query, regardless of the
+// order of the query parameters, every interaction is replayed once and in the recorded order,
+// so repeated requests, like automation status polls, get the successive recorded responses.
+//
+//	r, err := opsmngrtest.NewReplayer("testdata/cassette.json")
+//	client := opsmngr.NewClient(r)
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	replayed     []bool
+}
+
+// NewReplayer returns a Replayer for the cassette in path.
+func NewReplayer(path string) (*Replayer, error) {
+	c, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewCassetteReplayer(c), nil
+}
+
+// NewCassetteReplayer returns a Replayer for a loaded cassette.
+func NewCassetteReplayer(c *Cassette) *Replayer {
+	return &Replayer{interactions: c.Interactions, replayed: make([]bool, len(c.Interactions))}
+}
+
+// Do returns the response of the first interaction matching the request not replayed yet.
+func (r *Replayer) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		// consume and close the body like a transport would
+		_, _ = io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+	key := requestKey(req.Method, req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if r.replayed[i] {
+			continue
+		}
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recorded URL %s: %w", interaction.Request.URL, err)
+		}
+		if requestKey(interaction.Request.Method, u) != key {
+			continue
+		}
+		r.replayed[i] = true
+		return interaction.Response.response(req), nil
+	}
+	return nil, fmt.Errorf("%w for %s", ErrInteractionNotFound, key)
+}
+
+// Remaining returns the number of interactions not replayed yet.
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, replayed := range r.replayed {
+		if !replayed {
+			n++
+		}
+	}
+	return n
+}
+
+func (rr *RecordedResponse) response(req *http.Request) *http.Response {
+	header := rr.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.StatusCode, http.StatusText(rr.StatusCode)),
+		StatusCode:    rr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(rr.Body)),
+		ContentLength: int64(len(rr.Body)),
+		Request:       req,
+	}
+}
+
+// requestKey identifies a request by its method, path and query with sorted keys and values,
+// the values of sensitive query parameters are ignored as they are recorded as Redacted.
+func requestKey(method string, u *url.URL) string {
+	query := scrubQuery(u.Query())
+	for _, values := range query {
+		sort.Strings(values)
+	}
+	key := method + " " + u.Path
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+	return key
+}
+
+// requestBody returns the body of the request and the request to send to the transport.
+// A body that can't be read again is buffered on a clone of the request, so req isn't modified.
+func requestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer body.Close()
+		b, err := io.ReadAll(body)
+		return req, b, err
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(b))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	return out, b, nil
+}
+
+func scrubHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range sensitiveHeaders {
+		if values := h.Values(name); len(values) > 0 {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+// scrubURL returns a copy of u with the values of sensitive query parameters redacted.
+func scrubURL(u *url.URL) *url.URL {
+	scrubbed := *u
+	if u.RawQuery != "" {
+		scrubbed.RawQuery = scrubQuery(u.Query()).Encode()
+	}
+	return &scrubbed
+}
+
+// scrubQuery redacts the values of the sensitive fields of a query or form body.
+func scrubQuery(values url.Values) url.Values {
+	for k, v := range values {
+		if opsmngr.IsSensitiveField(k) {
+			for i := range v {
+				v[i] = Redacted
+			}
+		}
+	}
+	return values
+}
+
+// scrubBody redacts the sensitive fields of a JSON or form body, other bodies are recorded as they are.
+func scrubBody(h http.Header, b []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type")); err == nil && mediaType == formMediaType {
+		values, err := url.ParseQuery(string(b))
+		if err != nil {
+			// an invalid body could hold secrets the redaction can't find
+			return Redacted
+		}
+		return scrubQuery(values).Encode()
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return string(b)
+	}
+	scrubbed, err := json.Marshal(scrubValue("", v, false))
+	if err != nil {
+		return string(b)
+	}
+	return string(scrubbed)
+}
+
+// scrubValue redacts the string values of sensitive fields, and every string value when redact is set,
+// path is the JSON path of v in the body.
+func scrubValue(path string, v interface{}, redact bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			v[k] = scrubValue(p, e, redact || opsmngr.IsSensitivePath(p))
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = scrubValue(path, e, redact)
+		}
+	case string:
+		if redact {
+			return Redacted
+		}
+	}
+	return v
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/ops-manager/atmcfg"
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.mongodb.org/ops-manager/opsmngrtest"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecorderAndReplayer(t *testing.T) {
+	s := opsmngrtest.NewServer()
+	defer s.Close()
+	ctx := context.Background()
+	project := s.AddProject(s.AddOrganization("org").ID, "project")
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := opsmngrtest.NewRecorder(path, roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("Authorization", "Bearer token")
+		return http.DefaultTransport.RoundTrip(req)
+	}))
+	client := opsmngr.NewClient(&http.Client{Transport: recorder})
+	client.BaseURL, _ = url.Parse(s.URL)
+
+	_, err := atmcfg.Update(ctx, client.Automation, project.ID, func(out *opsmngr.AutomationConfig) error {
+		if err := atmcfg.EnableMechanism(out, []string{"SCRAM-SHA-256"}); err != nil {
+			return err
+		}
+		out.Auth.AutoPwd = "agentSecret"
+		u := &opsmngr.MongoDBUser{
+			Username:     "user",
+			Database:     "admin",
+			InitPassword: "userSecret",
+			Roles:        []*opsmngr.Role{{Role: "readWrite", Database: "test"}},
+		}
+		atmcfg.AddUser(out, u)
+		if err := atmcfg.AddIndexConfig(out, &opsmngr.IndexConfig{DBName: "test", CollectionName: "users", RSName: "rs", Key: [][]string{{"name", "1"}}}); err != nil {
+			return err
+		}
+		return atmcfg.ConfigureScramCredentials(u, "userSecret")
+	})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	recorded, _, err := client.Automation.GetConfig(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetConfig returned error: %v", err)
+	}
+	if _, _, err := client.Projects.List(ctx, &opsmngr.ListOptions{PageNum: 1, ItemsPerPage: 10}); err != nil {
+		t.Fatalf("Projects.List returned error: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if recorded.Auth.Key == "" {
+		t.Fatal("expected EnableMechanism to set the keyfile contents")
+	}
+	for _, secret := range []string{"Bearer token", "agentSecret", "userSecret", recorded.Auth.Key, recorded.Auth.UsersWanted[0].ScramSha256Creds.StoredKey} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("cassette contains secret %q", secret)
+		}
+	}
+
+	replayer, err := opsmngrtest.NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer returned error: %v", err)
+	}
+	client = opsmngr.NewClient(replayer)
+	client.BaseURL, _ = url.Parse("https://opsmanager.example.com/")
+
+	// Update reads the config, and again to check its version before updating it
+	config, _, err := client.Automation.GetConfig(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetConfig returned error: %v", err)
+	}
+	if _, _, err = client.Automation.GetConfig(ctx, project.ID); err != nil {
+		t.Fatalf("GetConfig returned error: %v", err)
+	}
+	if _, err := client.Automation.UpdateConfig(ctx, project.ID, config); err != nil {
+		t.Fatalf("UpdateConfig returned error: %v", err)
+	}
+	replayed, _, err := client.Automation.GetConfig(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetConfig returned error: %v", err)
+	}
+	if replayed.Version != recorded.Version || replayed.Auth.AutoPwd != opsmngrtest.Redacted ||
+		replayed.Auth.UsersWanted[0].ScramSha256Creds.StoredKey != opsmngrtest.Redacted || replayed.Auth.Key != opsmngrtest.Redacted {
+		t.Errorf("unexpected replayed config %+v", replayed.Auth)
+	}
+	if len(replayed.IndexConfigs) != 1 || !reflect.DeepEqual(replayed.IndexConfigs[0].Key, [][]string{{"name", "1"}}) {
+		t.Errorf("expected the index keys to be replayed, got %+v", replayed.IndexConfigs)
+	}
+	if _, _, err := client.Automation.GetConfig(ctx, project.ID); !errors.Is(err, opsmngrtest.ErrInteractionNotFound) {
+		t.Errorf("expected ErrInteractionNotFound once the interactions are replayed, got %v", err)
+	}
+
+	// query parameters match in any order
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://opsmanager.example.com/api/public/v1.0/groups?pageNum=1&itemsPerPage=10", nil)
+	resp, err := replayer.Do(req)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the recorded status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if replayer.Remaining() != 0 {
+		t.Errorf("expected every interaction to be replayed, %d left", replayer.Remaining())
+	}
+}
+
+func TestRecorder_requestWithoutGetBody(t *testing.T) {
+	var sent *http.Request
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := opsmngrtest.NewRecorder(path, roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if string(body) != `{"desc":"key"}` {
+			t.Errorf("expected the transport to read the whole body, got %s", body)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"desc":"key","privateKey":"privateSecret","publicKey":"public"}`)),
+			Request:    req,
+		}, nil
+	}))
+
+	body := io.NopCloser(strings.NewReader(`{"desc":"key"}`))
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://opsmanager.example.com/api/public/v1.0/orgs/1/apiKeys", body)
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip returned error: %v", err)
+	}
+	resp.Body.Close()
+	if sent == req || req.Body != body {
+		t.Error("expected the request to be cloned rather than modified")
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	cassette, err := opsmngrtest.LoadCassette(path)
+	if err != nil {
+		t.Fatalf("LoadCassette returned error: %v", err)
+	}
+	interaction := cassette.Interactions[0]
+	if interaction.Request.Body != `{"desc":"key"}` ||
+		interaction.Response.Body != `{"desc":"key","privateKey":"REDACTED","publicKey":"public"}` {
+		t.Errorf("unexpected recorded interaction %+v %+v", interaction.Request, interaction.Response)
+	}
+}
+
+func TestRecorder_formAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := opsmngrtest.NewRecorder(path, roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"access_token":"accessSecret","refresh_token":"newRefreshSecret","token_type":"Bearer"}`)),
+			Request:    req,
+		}, nil
+	}))
+
+	form := url.Values{"client_id": {"client"}, "refresh_token": {"refreshSecret"}, "grant_type": {"refresh_token"}}
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		"https://opsmanager.example.com/api/private/unauth/account/device/token?device_code=codeSecret&pageNum=1", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip returned error: %v", err)
+	}
+	resp.Body.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	for _, secret := range []string{"refreshSecret", "codeSecret", "accessSecret", "newRefreshSecret"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("cassette contains secret %q", secret)
+		}
+	}
+	cassette, err := opsmngrtest.LoadCassette(path)
+	if err != nil {
+		t.Fatalf("LoadCassette returned error: %v", err)
+	}
+	if body := cassette.Interactions[0].Request.Body; body != "client_id=client&grant_type=refresh_token&refresh_token=REDACTED" {
+		t.Errorf("unexpected recorded body %s", body)
+	}
+
+	// the redacted query parameters match any value
+	replayer := opsmngrtest.NewCassetteReplayer(cassette)
+	req, _ = http.NewRequestWithContext(context.Background(), http.MethodPost,
+		"https://opsmanager.example.com/api/private/unauth/account/device/token?pageNum=1&device_code=otherCode", nil)
+	if _, err := replayer.Do(req); err != nil {
+		t.Errorf("Do returned error: %v", err)
+	}
+}
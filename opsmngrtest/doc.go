@@ -0,0 +1,46 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package opsmngrtest provides an in-memory fake Ops Manager to test code built on the opsmngr package.
+
+The fake keeps organizations, projects, automation configs, automation status, hosts, alerts
+and continuous snapshots in memory and serves them with an httptest.Server, following the
+behavior of Ops Manager where it matters to clients: automation config updates are rejected
+unless they are based on the current version, every update bumps the version, and the
+automation status reaches the goal state a few polls after an update.
+
+# Usage
+
+	s := opsmngrtest.NewServer()
+	defer s.Close()
+
+	project := s.AddProject(s.AddOrganization("org").ID, "project")
+	client := s.Client()
+	config, _, err := client.Automation.GetConfig(ctx, project.ID)
+
+# Recording and replaying
+
+A Recorder records the interactions with a real Ops Manager in a cassette file, with credentials
+redacted, and a Replayer answers the same requests from the cassette without a server:
+
+	recorder := opsmngrtest.NewRecorder("testdata/cassette.json", transport)
+	client := opsmngr.NewClient(&http.Client{Transport: recorder})
+	...
+	err := recorder.Save()
+
+	replayer, err := opsmngrtest.NewReplayer("testdata/cassette.json")
+	client := opsmngr.NewClient(replayer)
+*/
+package opsmngrtest
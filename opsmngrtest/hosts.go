@@ -0,0 +1,129 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+// AddHost adds a monitored host to the project groupID and returns it with its ID set.
+func (s *Server) AddHost(groupID string, h *opsmngr.Host) (*opsmngr.Host, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, p := s.project(groupID)
+	if p == nil {
+		return nil, fmt.Errorf("project %s not found", groupID)
+	}
+	return copyJSON(s.addHost(p, h)), nil
+}
+
+func (s *Server) addHost(p *project, h *opsmngr.Host) *opsmngr.Host {
+	h = copyJSON(h)
+	h.ID = s.newID()
+	h.GroupID = p.ID
+	p.hosts = append(p.hosts, h)
+	return h
+}
+
+func (s *Server) hostRoutes() {
+	s.handle(http.MethodGet, "/groups/{groupID}/hosts", s.withProject(s.listHosts))
+	s.handle(http.MethodPost, "/groups/{groupID}/hosts", s.withProject(s.createHost))
+	s.handle(http.MethodGet, "/groups/{groupID}/hosts/byName/{hostAndPort}", s.withProject(s.getHostByName))
+	s.handle(http.MethodGet, "/groups/{groupID}/hosts/{hostID}", s.withProject(s.withHost(s.getHost)))
+	s.handle(http.MethodPatch, "/groups/{groupID}/hosts/{hostID}", s.withProject(s.withHost(s.updateHost)))
+	s.handle(http.MethodDelete, "/groups/{groupID}/hosts/{hostID}", s.withProject(s.withHost(s.deleteHost)))
+}
+
+// withHost calls f with the index of the host of the hostID parameter, or writes a not found error.
+func (s *Server) withHost(f func(http.ResponseWriter, *http.Request, *project, int)) func(http.ResponseWriter, *http.Request, params, *project) {
+	return func(w http.ResponseWriter, r *http.Request, params params, p *project) {
+		for i, h := range p.hosts {
+			if h.ID == params["hostID"] {
+				f(w, r, p, i)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, opsmngr.ErrorCodeHostNotFound, "No host with ID %s exists in group %s.", params["hostID"], p.ID)
+	}
+}
+
+func (s *Server) listHosts(w http.ResponseWriter, r *http.Request, _ params, p *project) {
+	clusterID := r.URL.Query().Get("clusterId")
+	var hosts []*opsmngr.Host
+	for _, h := range p.hosts {
+		if clusterID == "" || h.ClusterID == clusterID {
+			hosts = append(hosts, h)
+		}
+	}
+	start, end, links := page(r, len(hosts))
+	writeJSON(w, http.StatusOK, &opsmngr.Hosts{Links: links, Results: hosts[start:end], TotalCount: len(hosts)})
+}
+
+func (s *Server) createHost(w http.ResponseWriter, r *http.Request, _ params, p *project) {
+	h := new(opsmngr.Host)
+	if !readJSON(w, r, h) {
+		return
+	}
+	if h.Hostname == "" || h.Port == 0 {
+		writeError(w, http.StatusBadRequest, opsmngr.ErrorCodeMissingAttribute, "The required attributes hostname and port were not specified.")
+		return
+	}
+	for _, existing := range p.hosts {
+		if existing.Hostname == h.Hostname && existing.Port == h.Port {
+			writeError(w, http.StatusConflict, opsmngr.ErrorCodeHostAlreadyExists, "Host %s:%d already exists.", h.Hostname, h.Port)
+			return
+		}
+	}
+	writeJSON(w, http.StatusCreated, s.addHost(p, h))
+}
+
+func (s *Server) getHostByName(w http.ResponseWriter, _ *http.Request, params params, p *project) {
+	hostname, port, err := net.SplitHostPort(params["hostAndPort"])
+	if err == nil {
+		for _, h := range p.hosts {
+			if h.Hostname == hostname && strconv.Itoa(int(h.Port)) == port {
+				writeJSON(w, http.StatusOK, h)
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, opsmngr.ErrorCodeHostNotFound, "No host %s exists in group %s.", params["hostAndPort"], p.ID)
+}
+
+func (s *Server) getHost(w http.ResponseWriter, _ *http.Request, p *project, i int) {
+	writeJSON(w, http.StatusOK, p.hosts[i])
+}
+
+// updateHost updates the monitoring settings of a host, its identity can't change.
+func (s *Server) updateHost(w http.ResponseWriter, r *http.Request, p *project, i int) {
+	h := copyJSON(p.hosts[i])
+	if !readJSON(w, r, h) {
+		return
+	}
+	h.ID, h.GroupID = p.hosts[i].ID, p.ID
+	h.Hostname, h.Port = p.hosts[i].Hostname, p.hosts[i].Port
+	p.hosts[i] = h
+	writeJSON(w, http.StatusOK, h)
+}
+
+func (s *Server) deleteHost(w http.ResponseWriter, _ *http.Request, p *project, i int) {
+	p.hosts = append(p.hosts[:i], p.hosts[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
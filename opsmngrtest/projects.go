@@ -0,0 +1,217 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest
+
+import (
+	"net/http"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+// AddOrganization adds an organization with the given name.
+func (s *Server) AddOrganization(name string) *opsmngr.Organization {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyJSON(s.addOrganization(name))
+}
+
+// AddProject adds a project with the given name to the organization orgID,
+// its automation config has no deployment and auth disabled.
+func (s *Server) AddProject(orgID, name string) *opsmngr.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyJSON(s.addProject(orgID, name).Project)
+}
+
+func (s *Server) addOrganization(name string) *opsmngr.Organization {
+	o := &opsmngr.Organization{ID: s.newID(), Name: name}
+	s.orgs = append(s.orgs, o)
+	return o
+}
+
+func (s *Server) addProject(orgID, name string) *project {
+	p := &project{
+		Project: &opsmngr.Project{ID: s.newID(), OrgID: orgID, Name: name},
+		config: &opsmngr.AutomationConfig{
+			Auth:    opsmngr.Auth{Disabled: true},
+			Version: 1,
+		},
+	}
+	p.achieved = p.config.Version
+	s.projects = append(s.projects, p)
+	return p
+}
+
+func (s *Server) organization(id string) (int, *opsmngr.Organization) {
+	for i, o := range s.orgs {
+		if o.ID == id {
+			return i, o
+		}
+	}
+	return -1, nil
+}
+
+func (s *Server) project(id string) (int, *project) {
+	for i, p := range s.projects {
+		if p.ID == id {
+			return i, p
+		}
+	}
+	return -1, nil
+}
+
+// withProject calls f with the project of the groupID parameter, or writes a not found error.
+func (s *Server) withProject(f func(http.ResponseWriter, *http.Request, params, *project)) func(http.ResponseWriter, *http.Request, params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		_, project := s.project(p["groupID"])
+		if project == nil {
+			writeError(w, http.StatusNotFound, opsmngr.ErrorCodeGroupNotFound, "No group with ID %s exists.", p["groupID"])
+			return
+		}
+		f(w, r, p, project)
+	}
+}
+
+func (s *Server) projectRoutes() {
+	s.handle(http.MethodGet, "/orgs", s.listOrganizations)
+	s.handle(http.MethodPost, "/orgs", s.createOrganization)
+	s.handle(http.MethodGet, "/orgs/{orgID}", s.getOrganization)
+	s.handle(http.MethodDelete, "/orgs/{orgID}", s.deleteOrganization)
+	s.handle(http.MethodGet, "/orgs/{orgID}/groups", s.listOrganizationProjects)
+	s.handle(http.MethodGet, "/groups", s.listProjects)
+	s.handle(http.MethodPost, "/groups", s.createProject)
+	s.handle(http.MethodGet, "/groups/byName/{name}", s.getProjectByName)
+	s.handle(http.MethodGet, "/groups/{groupID}", s.withProject(s.getProject))
+	s.handle(http.MethodDelete, "/groups/{groupID}", s.withProject(s.deleteProject))
+}
+
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request, _ params) {
+	name := r.URL.Query().Get("name")
+	var orgs []*opsmngr.Organization
+	for _, o := range s.orgs {
+		if name == "" || o.Name == name {
+			orgs = append(orgs, o)
+		}
+	}
+	start, end, links := page(r, len(orgs))
+	writeJSON(w, http.StatusOK, &opsmngr.Organizations{Links: links, Results: orgs[start:end], TotalCount: len(orgs)})
+}
+
+func (s *Server) createOrganization(w http.ResponseWriter, r *http.Request, _ params) {
+	o := new(opsmngr.Organization)
+	if !readJSON(w, r, o) {
+		return
+	}
+	if o.Name == "" {
+		writeError(w, http.StatusBadRequest, opsmngr.ErrorCodeMissingAttribute, "The required attribute name was not specified.")
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.addOrganization(o.Name))
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, _ *http.Request, p params) {
+	_, o := s.organization(p["orgID"])
+	if o == nil {
+		writeError(w, http.StatusNotFound, opsmngr.ErrorCodeOrgNotFound, "No organization with ID %s exists.", p["orgID"])
+		return
+	}
+	writeJSON(w, http.StatusOK, o)
+}
+
+func (s *Server) deleteOrganization(w http.ResponseWriter, _ *http.Request, p params) {
+	i, o := s.organization(p["orgID"])
+	if o == nil {
+		writeError(w, http.StatusNotFound, opsmngr.ErrorCodeOrgNotFound, "No organization with ID %s exists.", p["orgID"])
+		return
+	}
+	for _, project := range s.projects {
+		if project.OrgID == o.ID {
+			writeError(w, http.StatusConflict, opsmngr.ErrorCodeCannotDeleteOrgActiveGroups, "Cannot delete organization %s with active groups.", o.ID)
+			return
+		}
+	}
+	s.orgs = append(s.orgs[:i], s.orgs[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listOrganizationProjects(w http.ResponseWriter, r *http.Request, p params) {
+	if _, o := s.organization(p["orgID"]); o == nil {
+		writeError(w, http.StatusNotFound, opsmngr.ErrorCodeOrgNotFound, "No organization with ID %s exists.", p["orgID"])
+		return
+	}
+	s.writeProjects(w, r, p["orgID"])
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, _ params) {
+	s.writeProjects(w, r, "")
+}
+
+// writeProjects writes the projects of the organization orgID, or all projects if it's empty.
+func (s *Server) writeProjects(w http.ResponseWriter, r *http.Request, orgID string) {
+	name := r.URL.Query().Get("name")
+	var projects []*opsmngr.Project
+	for _, p := range s.projects {
+		if (orgID == "" || p.OrgID == orgID) && (name == "" || p.Name == name) {
+			projects = append(projects, p.Project)
+		}
+	}
+	start, end, links := page(r, len(projects))
+	writeJSON(w, http.StatusOK, &opsmngr.Projects{Links: links, Results: projects[start:end], TotalCount: len(projects)})
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, _ params) {
+	req := new(opsmngr.Project)
+	if !readJSON(w, r, req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, opsmngr.ErrorCodeMissingAttribute, "The required attribute name was not specified.")
+		return
+	}
+	for _, p := range s.projects {
+		if p.Name == req.Name {
+			writeError(w, http.StatusConflict, opsmngr.ErrorCodeDuplicateGroupName, "A group with name %s already exists.", req.Name)
+			return
+		}
+	}
+	if req.OrgID == "" {
+		// Ops Manager creates an organization for projects created without one
+		req.OrgID = s.addOrganization(req.Name).ID
+	} else if _, o := s.organization(req.OrgID); o == nil {
+		writeError(w, http.StatusNotFound, opsmngr.ErrorCodeOrgNotFound, "No organization with ID %s exists.", req.OrgID)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.addProject(req.OrgID, req.Name).Project)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, _ *http.Request, _ params, p *project) {
+	writeJSON(w, http.StatusOK, p.Project)
+}
+
+func (s *Server) getProjectByName(w http.ResponseWriter, _ *http.Request, p params) {
+	for _, project := range s.projects {
+		if project.Name == p["name"] {
+			writeJSON(w, http.StatusOK, project.Project)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, opsmngr.ErrorCodeGroupNameNotFound, "No group with name %s exists.", p["name"])
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, _ *http.Request, _ params, p *project) {
+	i, _ := s.project(p.ID)
+	s.projects = append(s.projects[:i], s.projects[i+1:]...)
+	w.WriteHeader(http.StatusAccepted)
+}
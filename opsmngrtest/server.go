@@ -0,0 +1,243 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+const (
+	apiPath             = "/api/public/v1.0"
+	defaultItemsPerPage = 100
+)
+
+// Server is a fake Ops Manager keeping its state in memory.
+type Server struct {
+	// URL of the server, use it as the base URL of an opsmngr.Client.
+	URL string
+
+	server        *httptest.Server
+	routes        []*route
+	convergeAfter int
+
+	mu       sync.Mutex
+	lastID   int
+	orgs     []*opsmngr.Organization
+	projects []*project
+}
+
+// project is the state of a fake project.
+type project struct {
+	*opsmngr.Project
+	config    *opsmngr.AutomationConfig
+	achieved  int // achieved is the last config version the processes reached
+	pending   int // pending is the number of status polls before the processes reach the current version
+	hosts     []*opsmngr.Host
+	alerts    []*opsmngr.Alert
+	snapshots []*opsmngr.ContinuousSnapshot
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithConvergeAfter sets the number of automation status requests after an automation config
+// update before the processes reach the goal state, defaults to 1.
+func WithConvergeAfter(polls int) Option {
+	return func(s *Server) {
+		s.convergeAfter = polls
+	}
+}
+
+// NewServer starts a fake Ops Manager, call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{convergeAfter: 1}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.projectRoutes()
+	s.automationRoutes()
+	s.hostRoutes()
+	s.alertRoutes()
+	s.snapshotRoutes()
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL + "/"
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns an opsmngr.Client calling the server.
+func (s *Server) Client() *opsmngr.Client {
+	c := opsmngr.NewClient(s.server.Client())
+	c.BaseURL, _ = url.Parse(s.URL)
+	return c
+}
+
+// newID returns a new 24 hexadecimal digits identifier, like the ObjectIDs used by Ops Manager.
+func (s *Server) newID() string {
+	s.lastID++
+	return fmt.Sprintf("%024x", s.lastID)
+}
+
+type params map[string]string
+
+type route struct {
+	method   string
+	segments []string
+	handler  func(http.ResponseWriter, *http.Request, params)
+}
+
+// handle registers a handler for a method and a path relative to the API,
+// segments of the path in braces are parameters.
+func (s *Server) handle(method, path string, handler func(http.ResponseWriter, *http.Request, params)) {
+	s.routes = append(s.routes, &route{
+		method:   method,
+		segments: strings.Split(strings.Trim(apiPath+path, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (r *route) match(segments []string) (params, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	p := params{}
+	for i, s := range r.segments {
+		if strings.HasPrefix(s, "{") {
+			p[strings.Trim(s, "{}")] = segments[i]
+			continue
+		}
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+	return p, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	found := false
+	for _, route := range s.routes {
+		p, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		found = true
+		if route.method != r.Method {
+			continue
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		route.handler(w, r, p)
+		return
+	}
+	if found {
+		writeError(w, http.StatusMethodNotAllowed, opsmngr.ErrorCodeMethodNotAllowed, "%s is not allowed on %s", r.Method, r.URL.Path)
+		return
+	}
+	writeError(w, http.StatusNotFound, opsmngr.ErrorCodeResourceNotFound, "cannot find resource %s", r.URL.Path)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the format of opsmngr.ErrorResponse, the arguments of the detail are its parameters.
+func writeError(w http.ResponseWriter, status int, code opsmngr.ErrorCode, format string, a ...interface{}) {
+	writeJSON(w, status, &opsmngr.ErrorResponse{
+		HTTPCode:   status,
+		ErrorCode:  string(code),
+		Reason:     http.StatusText(status),
+		Detail:     fmt.Sprintf(format, a...),
+		Parameters: a,
+	})
+}
+
+// readJSON decodes the request body into v, writing an error if it's invalid.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, opsmngr.ErrorCodeInvalidJSON, "invalid JSON: %s", err.Error())
+		return false
+	}
+	return true
+}
+
+// page returns the bounds of the requested page of n results and the links of the page.
+func page(r *http.Request, n int) (start, end int, links []*opsmngr.Link) {
+	q := r.URL.Query()
+	pageNum, _ := strconv.Atoi(q.Get("pageNum"))
+	if pageNum < 1 {
+		pageNum = 1
+	}
+	itemsPerPage, _ := strconv.Atoi(q.Get("itemsPerPage"))
+	if itemsPerPage < 1 {
+		itemsPerPage = defaultItemsPerPage
+	}
+
+	start = (pageNum - 1) * itemsPerPage
+	if start > n {
+		start = n
+	}
+	end = start + itemsPerPage
+	if end > n {
+		end = n
+	}
+
+	link := func(rel string, num int) *opsmngr.Link {
+		u := *r.URL
+		u.Scheme, u.Host = "http", r.Host
+		q := u.Query()
+		q.Set("pageNum", strconv.Itoa(num))
+		q.Set("itemsPerPage", strconv.Itoa(itemsPerPage))
+		u.RawQuery = q.Encode()
+		return &opsmngr.Link{Rel: rel, Href: u.String()}
+	}
+	links = append(links, link("self", pageNum))
+	if pageNum > 1 {
+		links = append(links, link("previous", pageNum-1))
+	}
+	if end < n {
+		links = append(links, link("next", pageNum+1))
+	}
+	return start, end, links
+}
+
+// copyJSON returns a deep copy of v.
+func copyJSON[T any](v *T) *T {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	c := new(T)
+	if err := json.Unmarshal(b, c); err != nil {
+		panic(err)
+	}
+	return c
+}
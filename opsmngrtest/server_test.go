@@ -0,0 +1,208 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/ops-manager/atmcfg"
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.mongodb.org/ops-manager/opsmngrtest"
+)
+
+func TestServer_Projects(t *testing.T) {
+	s := opsmngrtest.NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+
+	org, _, err := client.Organizations.Create(ctx, &opsmngr.Organization{Name: "org"})
+	if err != nil {
+		t.Fatalf("Organizations.Create returned error: %v", err)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if _, _, err := client.Projects.Create(ctx, &opsmngr.Project{Name: name, OrgID: org.ID}, nil); err != nil {
+			t.Fatalf("Projects.Create returned error: %v", err)
+		}
+	}
+
+	_, _, err = client.Projects.Create(ctx, &opsmngr.Project{Name: "a", OrgID: org.ID}, nil)
+	if !errors.Is(err, opsmngr.ErrDuplicateGroupName) || !opsmngr.IsConflict(err) {
+		t.Errorf("expected a DUPLICATE_GROUP_NAME error, got %v", err)
+	}
+
+	projects, resp, err := client.Organizations.Projects(ctx, org.ID, &opsmngr.ProjectsListOptions{ListOptions: opsmngr.ListOptions{ItemsPerPage: 2}})
+	if err != nil {
+		t.Fatalf("Organizations.Projects returned error: %v", err)
+	}
+	if len(projects.Results) != 2 || projects.TotalCount != 3 || resp.IsLastPage() {
+		t.Errorf("expected the first page of 3 projects, got %d of %d", len(projects.Results), projects.TotalCount)
+	}
+
+	p, _, err := client.Projects.GetByName(ctx, "b")
+	if err != nil || p.OrgID != org.ID {
+		t.Fatalf("Projects.GetByName returned %v, %v", p, err)
+	}
+	if _, err := client.Projects.Delete(ctx, p.ID); err != nil {
+		t.Fatalf("Projects.Delete returned error: %v", err)
+	}
+	if _, _, err := client.Projects.Get(ctx, p.ID); !opsmngr.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestServer_Automation(t *testing.T) {
+	s := opsmngrtest.NewServer(opsmngrtest.WithConvergeAfter(2))
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	project := s.AddProject(s.AddOrganization("org").ID, "project")
+
+	config, err := atmcfg.Update(ctx, client.Automation, project.ID, func(out *opsmngr.AutomationConfig) error {
+		return atmcfg.NewReplicaSet("rs").
+			WithVersion("6.0.5").
+			WithMember("host0", 27017, nil).
+			WithMember("host1", 27017, nil).
+			AddTo(out)
+	})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	current, _, err := client.Automation.GetConfig(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetConfig returned error: %v", err)
+	}
+	if current.Version != config.Version+1 {
+		t.Errorf("expected the update to bump the version to %d, got %d", config.Version+1, current.Version)
+	}
+
+	// an update based on a previous version is rejected
+	_, err = client.Automation.UpdateConfig(ctx, project.ID, config)
+	if !errors.Is(err, opsmngr.ErrConflictingAutomationConfig) {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+
+	polls := 0
+	status, err := atmcfg.WaitForGoalState(ctx, client.Automation, project.ID, &atmcfg.WaitOptions{
+		Interval: time.Millisecond,
+		Progress: func(int, []*atmcfg.ProcessProgress) { polls++ },
+	})
+	if err != nil {
+		t.Fatalf("WaitForGoalState returned error: %v", err)
+	}
+	if polls != 3 || status.GoalVersion != 2 || len(status.Processes) != 2 {
+		t.Errorf("expected 2 processes in goal state after 3 polls, got %d processes after %d polls", len(status.Processes), polls)
+	}
+
+	stored, err := s.AutomationConfig(project.ID)
+	if err != nil {
+		t.Fatalf("AutomationConfig returned error: %v", err)
+	}
+	if len(stored.Processes) != 2 || len(stored.ReplicaSets) != 1 {
+		t.Errorf("unexpected stored config %+v", stored)
+	}
+}
+
+func TestServer_Hosts(t *testing.T) {
+	s := opsmngrtest.NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	project := s.AddProject(s.AddOrganization("org").ID, "project")
+
+	h, _, err := client.Deployments.StartMonitoring(ctx, project.ID, &opsmngr.Host{Hostname: "host0", Port: 27017, ClusterID: "cluster"})
+	if err != nil {
+		t.Fatalf("StartMonitoring returned error: %v", err)
+	}
+	if _, err := s.AddHost(project.ID, &opsmngr.Host{Hostname: "host1", Port: 27017}); err != nil {
+		t.Fatalf("AddHost returned error: %v", err)
+	}
+
+	hosts, _, err := client.Deployments.ListHosts(ctx, project.ID, &opsmngr.HostListOptions{ClusterID: "cluster"})
+	if err != nil || len(hosts.Results) != 1 || hosts.Results[0].ID != h.ID {
+		t.Fatalf("ListHosts returned %v, %v", hosts, err)
+	}
+	if got, _, err := client.Deployments.GetHostByHostname(ctx, project.ID, "host0", 27017); err != nil || got.ID != h.ID {
+		t.Fatalf("GetHostByHostname returned %v, %v", got, err)
+	}
+
+	enabled := true
+	updated, _, err := client.Deployments.UpdateMonitoring(ctx, project.ID, h.ID, &opsmngr.Host{LogsEnabled: &enabled})
+	if err != nil || updated.LogsEnabled == nil || !*updated.LogsEnabled || updated.Hostname != "host0" {
+		t.Fatalf("UpdateMonitoring returned %v, %v", updated, err)
+	}
+	if _, err := client.Deployments.StopMonitoring(ctx, project.ID, h.ID); err != nil {
+		t.Fatalf("StopMonitoring returned error: %v", err)
+	}
+	if _, _, err := client.Deployments.GetHost(ctx, project.ID, h.ID); err == nil {
+		t.Error("expected an error for a deleted host")
+	}
+}
+
+func TestServer_Alerts(t *testing.T) {
+	s := opsmngrtest.NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	project := s.AddProject(s.AddOrganization("org").ID, "project")
+
+	a, err := s.AddAlert(project.ID, &opsmngr.Alert{EventTypeName: "HOST_DOWN"})
+	if err != nil {
+		t.Fatalf("AddAlert returned error: %v", err)
+	}
+	if _, err := s.AddAlert(project.ID, &opsmngr.Alert{EventTypeName: "HOST_DOWN", Status: "CLOSED"}); err != nil {
+		t.Fatalf("AddAlert returned error: %v", err)
+	}
+
+	alerts, _, err := client.Alerts.List(ctx, project.ID, &opsmngr.AlertsListOptions{Status: "OPEN"})
+	if err != nil || len(alerts.Results) != 1 || alerts.Results[0].ID != a.ID {
+		t.Fatalf("Alerts.List returned %v, %v", alerts, err)
+	}
+
+	until := "2026-01-01T00:00:00Z"
+	acked, _, err := client.Alerts.Acknowledge(ctx, project.ID, a.ID, &opsmngr.AcknowledgeRequest{AcknowledgedUntil: &until, AcknowledgementComment: "on it"})
+	if err != nil || acked.AcknowledgedUntil != until || acked.AcknowledgementComment != "on it" {
+		t.Fatalf("Alerts.Acknowledge returned %v, %v", acked, err)
+	}
+}
+
+func TestServer_Snapshots(t *testing.T) {
+	s := opsmngrtest.NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	project := s.AddProject(s.AddOrganization("org").ID, "project")
+
+	snapshot, err := s.AddSnapshot(project.ID, "cluster", &opsmngr.ContinuousSnapshot{Complete: true})
+	if err != nil {
+		t.Fatalf("AddSnapshot returned error: %v", err)
+	}
+
+	snapshots, _, err := client.ContinuousSnapshots.List(ctx, project.ID, "cluster", nil)
+	if err != nil || len(snapshots.Results) != 1 {
+		t.Fatalf("ContinuousSnapshots.List returned %v, %v", snapshots, err)
+	}
+
+	doNotDelete := true
+	if _, _, err := client.ContinuousSnapshots.ChangeExpiry(ctx, project.ID, "cluster", snapshot.ID, &opsmngr.ContinuousSnapshot{DoNotDelete: &doNotDelete}); err != nil {
+		t.Fatalf("ContinuousSnapshots.ChangeExpiry returned error: %v", err)
+	}
+	if _, err := client.ContinuousSnapshots.Delete(ctx, project.ID, "cluster", snapshot.ID); err == nil {
+		t.Error("expected an error deleting a snapshot marked as do not delete")
+	}
+}
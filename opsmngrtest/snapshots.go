@@ -0,0 +1,100 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngrtest
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+)
+
+// AddSnapshot adds a continuous snapshot of the cluster clusterID to the project groupID
+// and returns it with its ID set.
+func (s *Server) AddSnapshot(groupID, clusterID string, snapshot *opsmngr.ContinuousSnapshot) (*opsmngr.ContinuousSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, p := s.project(groupID)
+	if p == nil {
+		return nil, fmt.Errorf("project %s not found", groupID)
+	}
+	snapshot = copyJSON(snapshot)
+	snapshot.ID = s.newID()
+	snapshot.GroupID = p.ID
+	snapshot.ClusterID = clusterID
+	p.snapshots = append(p.snapshots, snapshot)
+	return copyJSON(snapshot), nil
+}
+
+func (s *Server) snapshotRoutes() {
+	const path = "/groups/{groupID}/clusters/{clusterID}/snapshots"
+	s.handle(http.MethodGet, path, s.withProject(s.listSnapshots))
+	s.handle(http.MethodGet, path+"/{snapshotID}", s.withProject(s.withSnapshot(s.getSnapshot)))
+	s.handle(http.MethodPatch, path+"/{snapshotID}", s.withProject(s.withSnapshot(s.updateSnapshot)))
+	s.handle(http.MethodDelete, path+"/{snapshotID}", s.withProject(s.withSnapshot(s.deleteSnapshot)))
+}
+
+// withSnapshot calls f with the index of the snapshot of the snapshotID parameter, or writes a not found error.
+func (s *Server) withSnapshot(f func(http.ResponseWriter, *http.Request, *project, int)) func(http.ResponseWriter, *http.Request, params, *project) {
+	return func(w http.ResponseWriter, r *http.Request, params params, p *project) {
+		for i, snapshot := range p.snapshots {
+			if snapshot.ID == params["snapshotID"] && snapshot.ClusterID == params["clusterID"] {
+				f(w, r, p, i)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, opsmngr.ErrorCodeSnapshotNotFound, "No snapshot with ID %s exists for cluster %s.", params["snapshotID"], params["clusterID"])
+	}
+}
+
+func (s *Server) listSnapshots(w http.ResponseWriter, r *http.Request, params params, p *project) {
+	var snapshots []*opsmngr.ContinuousSnapshot
+	for _, snapshot := range p.snapshots {
+		if snapshot.ClusterID == params["clusterID"] {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	start, end, links := page(r, len(snapshots))
+	writeJSON(w, http.StatusOK, &opsmngr.ContinuousSnapshots{Links: links, Results: snapshots[start:end], TotalCount: len(snapshots)})
+}
+
+func (s *Server) getSnapshot(w http.ResponseWriter, _ *http.Request, p *project, i int) {
+	writeJSON(w, http.StatusOK, p.snapshots[i])
+}
+
+// updateSnapshot changes the expiration of a snapshot, the only field Ops Manager allows to change.
+func (s *Server) updateSnapshot(w http.ResponseWriter, r *http.Request, p *project, i int) {
+	req := new(opsmngr.ContinuousSnapshot)
+	if !readJSON(w, r, req) {
+		return
+	}
+	snapshot := p.snapshots[i]
+	if req.DoNotDelete != nil {
+		snapshot.DoNotDelete = req.DoNotDelete
+	}
+	if req.Expires != "" {
+		snapshot.Expires = req.Expires
+	}
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+func (s *Server) deleteSnapshot(w http.ResponseWriter, _ *http.Request, p *project, i int) {
+	if p.snapshots[i].DoNotDelete != nil && *p.snapshots[i].DoNotDelete {
+		writeError(w, http.StatusBadRequest, opsmngr.ErrorCodeCannotDeleteSnapshot, "Snapshot %s is marked as do not delete.", p.snapshots[i].ID)
+		return
+	}
+	p.snapshots = append(p.snapshots[:i], p.snapshots[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,26 @@
+module go.mongodb.org/ops-manager/otelopsmngr
+
+// go 1.21 matches the root module, otel is kept at v1.29.0, its last release supporting Go 1.21.
+go 1.21
+
+require (
+	go.mongodb.org/ops-manager v0.56.0
+	go.opentelemetry.io/otel v1.29.0
+	go.opentelemetry.io/otel/metric v1.29.0
+	go.opentelemetry.io/otel/sdk v1.29.0
+	go.opentelemetry.io/otel/sdk/metric v1.29.0
+	go.opentelemetry.io/otel/trace v1.29.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	golang.org/x/sys v0.24.0 // indirect
+)
+
+// otelopsmngr only uses the API of the released v0.56.0 of the root module, so it can be tagged on its own.
+// The replace builds it, and its tests using opsmngrtest, against the root module of this repository,
+// users of otelopsmngr ignore it.
+replace go.mongodb.org/ops-manager => ../
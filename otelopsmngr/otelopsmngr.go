@@ -0,0 +1,298 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package otelopsmngr instruments the calls of an opsmngr.Client with OpenTelemetry traces and metrics.
+
+Every API request gets a client span named after its method and templated path, like
+GET /groups/{groupId}/automationConfig, and is measured by a latency histogram and an error counter.
+
+# Usage
+
+	httpClient, err := otelopsmngr.NewHTTPClient(opsmngr.NewDigestTransport(publicKey, privateKey).Client())
+	if err != nil {
+		return err
+	}
+	client := opsmngr.NewClient(httpClient)
+
+The tracer and meter providers default to the global ones, see WithTracerProvider and WithMeterProvider.
+*/
+package otelopsmngr
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ScopeName is the instrumentation scope of the tracer and meter.
+	ScopeName = "go.mongodb.org/ops-manager/otelopsmngr"
+
+	defaultServiceName = "ops-manager"
+	apiPath            = "/api/public/v1.0"
+	maxErrorBodyLength = 64 * 1024
+	minIDLength        = 24
+)
+
+// Attributes set on spans and metrics.
+const (
+	PeerServiceKey    = attribute.Key("peer.service")
+	MethodKey         = attribute.Key("http.request.method")
+	URLTemplateKey    = attribute.Key("url.template")
+	ServerAddressKey  = attribute.Key("server.address")
+	StatusCodeKey     = attribute.Key("http.response.status_code")
+	ErrorCodeKey      = attribute.Key("opsmngr.error_code")
+	ServiceVersionKey = attribute.Key("opsmngr.service_version")
+)
+
+// Metrics recorded for every request.
+const (
+	DurationMetricName = "opsmngr.client.request.duration"
+	ErrorsMetricName   = "opsmngr.client.request.errors"
+)
+
+// HTTPClient is an opsmngr.HTTPClient recording a span and metrics for every request.
+type HTTPClient struct {
+	client      opsmngr.HTTPClient
+	serviceName string
+	tracer      trace.Tracer
+	duration    metric.Float64Histogram
+	errors      metric.Int64Counter
+}
+
+var _ opsmngr.HTTPClient = &HTTPClient{}
+
+type config struct {
+	serviceName    string
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures an HTTPClient.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider creating the spans, defaults to the global one.
+func WithTracerProvider(p trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = p
+	}
+}
+
+// WithMeterProvider sets the MeterProvider recording the metrics, defaults to the global one.
+func WithMeterProvider(p metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = p
+	}
+}
+
+// WithServiceName sets the peer.service attribute naming the Ops Manager instance called, defaults to ops-manager.
+func WithServiceName(name string) Option {
+	return func(c *config) {
+		c.serviceName = name
+	}
+}
+
+// NewHTTPClient returns an HTTPClient instrumenting the requests sent with client,
+// http.DefaultClient if nil.
+func NewHTTPClient(client opsmngr.HTTPClient, opts ...Option) (*HTTPClient, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	c := &config{
+		serviceName:    defaultServiceName,
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram(DurationMetricName,
+		metric.WithDescription("Duration of the Ops Manager API requests."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	errs, err := meter.Int64Counter(ErrorsMetricName,
+		metric.WithDescription("Number of Ops Manager API requests that failed or returned an error."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTPClient{
+		client:      client,
+		serviceName: c.serviceName,
+		tracer:      c.tracerProvider.Tracer(ScopeName),
+		duration:    duration,
+		errors:      errs,
+	}, nil
+}
+
+// Do sends the request with the underlying client in a client span, and records its duration.
+func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
+	template := PathTemplate(req.URL.EscapedPath())
+	attrs := []attribute.KeyValue{
+		PeerServiceKey.String(c.serviceName),
+		MethodKey.String(req.Method),
+		URLTemplateKey.String(template),
+		ServerAddressKey.String(req.URL.Hostname()),
+	}
+	ctx, span := c.tracer.Start(req.Context(), req.Method+" "+template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := c.client.Do(req.WithContext(ctx))
+	elapsed := time.Since(start).Seconds()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+		c.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		return resp, err
+	}
+
+	attrs = append(attrs, StatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		description := http.StatusText(resp.StatusCode)
+		if code := errorCode(resp); code != "" {
+			attrs = append(attrs, ErrorCodeKey.String(code))
+			description = string(code)
+		}
+		span.SetStatus(codes.Error, description)
+		c.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	span.SetAttributes(attrs...)
+	if v := resp.Header.Get("X-MongoDB-Service-Version"); v != "" {
+		span.SetAttributes(ServiceVersionKey.String(v))
+	}
+	c.duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+	return resp, nil
+}
+
+// errorCode reads the error code from the body of an error response, leaving the body readable.
+func errorCode(resp *http.Response) string {
+	if resp.Body == nil {
+		return ""
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+	if err != nil {
+		return ""
+	}
+	var errResp opsmngr.ErrorResponse
+	if json.Unmarshal(data, &errResp) != nil {
+		return ""
+	}
+	return errResp.ErrorCode
+}
+
+// pathParameters names the parameter following each collection of the API, like groupId after groups.
+var pathParameters = map[string]string{
+	"accessList":         "ipAddress",
+	"agentapikeys":       "agentApiKeyId",
+	"agents":             "agentType",
+	"alertConfigs":       "alertConfigId",
+	"alerts":             "alertId",
+	"apiKeys":            "apiKeyId",
+	"backupConfigs":      "clusterId",
+	"byName":             "name",
+	"checkpoints":        "checkpointId",
+	"clusters":           "clusterId",
+	"configs":            "configId",
+	"databases":          "databaseName",
+	"disks":              "partitionName",
+	"events":             "eventId",
+	"fileSystemConfigs":  "configId",
+	"globalAlerts":       "alertId",
+	"groups":             "groupId",
+	"hosts":              "hostId",
+	"invites":            "invitationId",
+	"logCollectionJobs":  "jobId",
+	"maintenanceWindows": "maintenanceWindowId",
+	"mongoConfigs":       "configId",
+	"organizations":      "orgId",
+	"orgs":               "orgId",
+	"policies":           "policyId",
+	"processes":          "processId",
+	"restoreJobs":        "jobId",
+	"s3Configs":          "configId",
+	"snapshots":          "snapshotId",
+	"teams":              "teamId",
+	"users":              "userId",
+	"version_manifest":   "version",
+	"whitelist":          "ipAddress",
+}
+
+// staticSegments are the resources following a collection that aren't parameters, like byName in groups/byName.
+var staticSegments = map[string]bool{
+	"availablePolicies": true,
+	"byName":            true,
+	"matchers":          true,
+	"versions":          true,
+}
+
+// PathTemplate returns the path relative to the API with identifiers replaced by parameters,
+// like /groups/{groupId}/automationConfig for /api/public/v1.0/groups/5e43de.../automationConfig.
+// Any segment following a known collection, like hosts or databases, is a parameter named after it,
+// so host IDs, database names or host:port never end up in span names or metric attributes.
+// Hexadecimal identifiers following unknown collections are replaced by {id}.
+func PathTemplate(path string) string {
+	if i := strings.Index(path, apiPath); i >= 0 {
+		path = path[i+len(apiPath):]
+	}
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] == "" || staticSegments[segments[i]] {
+			continue
+		}
+		if param, ok := pathParameters[segments[i-1]]; ok {
+			segments[i] = "{" + param + "}"
+		} else if isHexID(segments[i]) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isHexID reports whether s looks like an identifier, an ObjectID or a longer hexadecimal ID like the ones of hosts.
+func isHexID(s string) bool {
+	if len(s) < minIDLength {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
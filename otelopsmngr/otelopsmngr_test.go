@@ -0,0 +1,159 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelopsmngr_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"go.mongodb.org/ops-manager/opsmngr"
+	"go.mongodb.org/ops-manager/opsmngrtest"
+	"go.mongodb.org/ops-manager/otelopsmngr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceVersion = "gitHash=f2a1e0f; versionString=7.0.2"
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHTTPClient(t *testing.T) {
+	s := opsmngrtest.NewServer()
+	defer s.Close()
+	ctx := context.Background()
+	project := s.AddProject(s.AddOrganization("org").ID, "project")
+
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	httpClient, err := otelopsmngr.NewHTTPClient(
+		&http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if err == nil {
+				resp.Header.Set("X-MongoDB-Service-Version", serviceVersion)
+			}
+			return resp, err
+		})},
+		otelopsmngr.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))),
+		otelopsmngr.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		otelopsmngr.WithServiceName("om"),
+	)
+	if err != nil {
+		t.Fatalf("NewHTTPClient returned error: %v", err)
+	}
+	client := opsmngr.NewClient(httpClient)
+	client.BaseURL, _ = url.Parse(s.URL)
+
+	if _, _, err := client.Automation.GetConfig(ctx, project.ID); err != nil {
+		t.Fatalf("GetConfig returned error: %v", err)
+	}
+	if _, _, err := client.Projects.Get(ctx, "000000000000000000000000"); !errors.Is(err, opsmngr.ErrGroupNotFound) {
+		t.Fatalf("expected the error of the response to be readable by the client, got %v", err)
+	}
+
+	ended := spans.GetSpans()
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	ok, notFound := ended[0], ended[1]
+	if ok.Name != "GET /groups/{groupId}/automationConfig" || ok.SpanKind != trace.SpanKindClient || ok.Status.Code == codes.Error {
+		t.Errorf("unexpected span %s, kind %v, status %v", ok.Name, ok.SpanKind, ok.Status)
+	}
+	assertAttributes(t, ok.Attributes, map[attribute.Key]attribute.Value{
+		otelopsmngr.PeerServiceKey:    attribute.StringValue("om"),
+		otelopsmngr.MethodKey:         attribute.StringValue(http.MethodGet),
+		otelopsmngr.URLTemplateKey:    attribute.StringValue("/groups/{groupId}/automationConfig"),
+		otelopsmngr.StatusCodeKey:     attribute.IntValue(http.StatusOK),
+		otelopsmngr.ServiceVersionKey: attribute.StringValue(serviceVersion),
+	})
+	if notFound.Status.Code != codes.Error || notFound.Status.Description != string(opsmngr.ErrorCodeGroupNotFound) {
+		t.Errorf("expected an error status, got %v", notFound.Status)
+	}
+	assertAttributes(t, notFound.Attributes, map[attribute.Key]attribute.Value{
+		otelopsmngr.URLTemplateKey: attribute.StringValue("/groups/{groupId}"),
+		otelopsmngr.StatusCodeKey:  attribute.IntValue(http.StatusNotFound),
+		otelopsmngr.ErrorCodeKey:   attribute.StringValue(string(opsmngr.ErrorCodeGroupNotFound)),
+	})
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	var requests, failures uint64
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Histogram[float64]:
+			for _, p := range data.DataPoints {
+				requests += p.Count
+			}
+		case metricdata.Sum[int64]:
+			for _, p := range data.DataPoints {
+				if code, _ := p.Attributes.Value(otelopsmngr.ErrorCodeKey); code.AsString() == string(opsmngr.ErrorCodeGroupNotFound) {
+					failures += uint64(p.Value)
+				}
+			}
+		}
+	}
+	if requests != 2 || failures != 1 {
+		t.Errorf("expected 2 requests and 1 failure, got %d and %d", requests, failures)
+	}
+}
+
+func assertAttributes(t *testing.T, attrs []attribute.KeyValue, expected map[attribute.Key]attribute.Value) {
+	t.Helper()
+	set := attribute.NewSet(attrs...)
+	for k, v := range expected {
+		if got, ok := set.Value(k); !ok || got != v {
+			t.Errorf("expected attribute %s=%s, got %s", k, v.Emit(), got.Emit())
+		}
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/api/public/v1.0/groups/5e43de12345678901234abcd/automationConfig":                                                    "/groups/{groupId}/automationConfig",
+		"/api/public/v1.0/groups/byName/project":                                                                               "/groups/byName/{name}",
+		"/api/public/v1.0/groups/availablePolicies":                                                                            "/groups/availablePolicies",
+		"/api/public/v1.0/orgs/5e43de12345678901234abcd/groups":                                                                "/orgs/{orgId}/groups",
+		"/api/public/v1.0/groups/5e43de12345678901234abcd/clusters/5e43de12345678901234abce/snapshots":                         "/groups/{groupId}/clusters/{clusterId}/snapshots",
+		"/ops/api/public/v1.0/groups/5e43de12345678901234abcd/hosts/0a1b2c3d4e5f60718293a4b5c6d7e8f9":                          "/groups/{groupId}/hosts/{hostId}",
+		"/api/public/v1.0/groups/5e43de12345678901234abcd/hosts/0a1b2c3d4e5f60718293a4b5c6d7e8f9/databases/sales/measurements": "/groups/{groupId}/hosts/{hostId}/databases/{databaseName}/measurements",
+		"/api/public/v1.0/groups/5e43de12345678901234abcd/hosts/0a1b2c3d4e5f60718293a4b5c6d7e8f9/disks/data/measurements":      "/groups/{groupId}/hosts/{hostId}/disks/{partitionName}/measurements",
+		"/api/public/v1.0/groups/5e43de12345678901234abcd/processes/mongo-0.example.com:27017/measurements":                    "/groups/{groupId}/processes/{processId}/measurements",
+		"/api/public/v1.0/groups/5e43de12345678901234abcd/hosts/byName/mongo-0.example.com:27017":                              "/groups/{groupId}/hosts/byName/{name}",
+		"/api/public/v1.0/users/byName/jane.doe@example.com":                                                                   "/users/byName/{name}",
+		"/api/public/v1.0/orgs/5e43de12345678901234abcd/apiKeys/5e43de12345678901234abce/accessList/10.0.0.0%2F8":              "/orgs/{orgId}/apiKeys/{apiKeyId}/accessList/{ipAddress}",
+		"/api/public/v1.0/groups/5e43de12345678901234abcd/policies/5e43de12345678901234abce":                                   "/groups/{groupId}/policies/{policyId}",
+		"/api/public/v1.0/groups/5e43de12345678901234abcd/agents/versions":                                                     "/groups/{groupId}/agents/versions",
+		"/api/public/v1.0/unknown/5e43de12345678901234abcd":                                                                    "/unknown/{id}",
+		"/api/public/v1.0/unauth/users":                                                                                        "/unauth/users",
+	}
+	for path, expected := range tests {
+		if got := otelopsmngr.PathTemplate(path); got != expected {
+			t.Errorf("PathTemplate(%s) = %s, expected %s", path, got, expected)
+		}
+	}
+}
@@ -189,6 +189,15 @@ var fixture = &opsmngr.AutomationConfig{
 			Collation: nil,
 		},
 	},
+	Roles: []*opsmngr.CustomRole{
+		{
+			Role:     "testRole",
+			Database: "test",
+			Roles: []*opsmngr.Role{
+				{Role: "read", Database: "test"},
+			},
+		},
+	},
 	Version: 1,
 }
 
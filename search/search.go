@@ -71,6 +71,19 @@ func ShardingConfig(a []*opsmngr.ShardingConfig, f func(*opsmngr.ShardingConfig)
 	return len(a), false
 }
 
+// Shards return the smallest index i
+// in [0, n) at which f(i) is true, assuming that on the range [0, n),
+// f(i) == true implies f(i+1) == true.
+// returns the first true index. If there is no such index, Shards returns n and false.
+func Shards(a []*opsmngr.Shard, f func(*opsmngr.Shard) bool) (int, bool) {
+	for i, m := range a {
+		if f(m) {
+			return i, true
+		}
+	}
+	return len(a), false
+}
+
 // MongoDBUsers return the smallest index i
 // in [0, n) at which f(i) is true, assuming that on the range [0, n),
 // f(i) == true implies f(i+1) == true.
@@ -96,3 +109,16 @@ func MongoDBIndexes(a []*opsmngr.IndexConfig, f func(configs *opsmngr.IndexConfi
 	}
 	return len(a), false
 }
+
+// CustomRoles return the smallest index i
+// in [0, n) at which f(i) is true, assuming that on the range [0, n),
+// f(i) == true implies f(i+1) == true.
+// returns the first true index. If there is no such index, CustomRoles returns n and false.
+func CustomRoles(a []*opsmngr.CustomRole, f func(*opsmngr.CustomRole) bool) (int, bool) {
+	for i, m := range a {
+		if f(m) {
+			return i, true
+		}
+	}
+	return len(a), false
+}
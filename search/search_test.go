@@ -109,6 +109,27 @@ func TestShardingConfig(t *testing.T) {
 	})
 }
 
+func TestShards(t *testing.T) {
+	shards := []*opsmngr.Shard{{ID: "myShard_0", RS: "myShard_0"}}
+	t.Run("value exists", func(t *testing.T) {
+		_, e := search.Shards(shards, func(s *opsmngr.Shard) bool {
+			return s.ID == "myShard_0"
+		})
+		if !e {
+			t.Error("Shards() should find the value")
+		}
+	})
+
+	t.Run("value does not exists", func(t *testing.T) {
+		i, e := search.Shards(shards, func(s *opsmngr.Shard) bool {
+			return s.ID == "other_shard"
+		})
+		if e {
+			t.Errorf("Shards() found at: %d", i)
+		}
+	})
+}
+
 func TestMongoDBUsers(t *testing.T) {
 	users := fixture.Auth.UsersWanted
 	t.Run("value exists", func(t *testing.T) {
@@ -150,3 +171,24 @@ func TestMongoDBIndexes(t *testing.T) {
 		}
 	})
 }
+
+func TestCustomRoles(t *testing.T) {
+	roles := fixture.Roles
+	t.Run("value exists", func(t *testing.T) {
+		_, e := search.CustomRoles(roles, func(r *opsmngr.CustomRole) bool {
+			return r.Role == "testRole" && r.Database == "test"
+		})
+		if !e {
+			t.Error("CustomRoles() should find the value")
+		}
+	})
+
+	t.Run("value does not exists", func(t *testing.T) {
+		i, e := search.CustomRoles(roles, func(r *opsmngr.CustomRole) bool {
+			return r.Role == "other_role"
+		})
+		if e {
+			t.Errorf("CustomRoles() found at: %d", i)
+		}
+	})
+}